@@ -18,9 +18,13 @@ type ObjectType string
 
 // Default Object types
 const (
-	ObjectTypeContact ObjectType = "contacts"
-	ObjectTypeDeal    ObjectType = "deals"
-	ObjectTypeCompany ObjectType = "company"
+	ObjectTypeContact  ObjectType = "contacts"
+	ObjectTypeDeal     ObjectType = "deals"
+	ObjectTypeCompany  ObjectType = "company"
+	ObjectTypeTicket   ObjectType = "tickets"
+	ObjectTypeProduct  ObjectType = "products"
+	ObjectTypeLineItem ObjectType = "line_items"
+	ObjectTypeNote     ObjectType = "notes"
 )
 
 // AssociationType is the name of the key used to associate the objects together.
@@ -1,6 +1,7 @@
 package hubspot
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -11,18 +12,44 @@ type Authenticator interface {
 
 type AuthMethod func(c *Client)
 
-func SetOAuth(config *OAuthConfig) AuthMethod {
+// OAuthOption configures the OAuthTokenManager created by SetOAuth.
+type OAuthOption func(otm *OAuthTokenManager)
+
+// WithTokenStore persists the OAuth tokens in the store.
+// Use a FileTokenStore to keep the token across restarts and to share it between the processes of a host.
+func WithTokenStore(store TokenStore) OAuthOption {
+	return func(otm *OAuthTokenManager) {
+		otm.Store = store
+	}
+}
+
+func SetOAuth(config *OAuthConfig, opts ...OAuthOption) AuthMethod {
 	return func(c *Client) {
+		otm := &OAuthTokenManager{
+			oauthPath:  fmt.Sprintf("%s/%s", c.baseURL.String(), oauthTokenPath),
+			HTTPClient: c.HTTPClient,
+			Config:     config,
+		}
+		for _, opt := range opts {
+			opt(otm)
+		}
 		c.authenticator = &OAuth{
-			retriever: &OAuthTokenManager{
-				oauthPath:  fmt.Sprintf("%s/%s", c.baseURL.String(), oauthTokenPath),
-				HTTPClient: c.HTTPClient,
-				Config:     config,
-			},
+			retriever: otm,
 		}
 	}
 }
 
+// oauthTokenManager returns the OAuthTokenManager set by SetOAuth.
+// The authentication method is set before the options, which update the token manager through it.
+func (c *Client) oauthTokenManager() (*OAuthTokenManager, bool) {
+	o, ok := c.authenticator.(*OAuth)
+	if !ok {
+		return nil, false
+	}
+	otm, ok := o.retriever.(*OAuthTokenManager)
+	return otm, ok
+}
+
 // Deprecated: Use hubspot.SetPrivateAppToken.
 func SetAPIKey(key string) AuthMethod {
 	return func(c *Client) {
@@ -32,6 +59,12 @@ func SetAPIKey(key string) AuthMethod {
 	}
 }
 
+// SetDeveloperAPIKey authenticates with the developer API key of an app developer account.
+// It is required by the developer APIs, e.g. the Webhooks service.
+func SetDeveloperAPIKey(key string) AuthMethod {
+	return SetAPIKey(key)
+}
+
 func SetPrivateAppToken(token string) AuthMethod {
 	return func(c *Client) {
 		c.authenticator = &PrivateAppToken{
@@ -45,7 +78,7 @@ type OAuth struct {
 }
 
 func (o *OAuth) SetAuthentication(r *http.Request) error {
-	t, err := o.retriever.RetrieveToken()
+	t, err := o.token(r.Context())
 	if err != nil {
 		return err
 	}
@@ -53,6 +86,14 @@ func (o *OAuth) SetAuthentication(r *http.Request) error {
 	return nil
 }
 
+// token retrieves the token, with the context when the retriever supports it.
+func (o *OAuth) token(ctx context.Context) (*OAuthToken, error) {
+	if cr, ok := o.retriever.(OAuthTokenContextRetriever); ok {
+		return cr.RetrieveTokenWithContext(ctx)
+	}
+	return o.retriever.RetrieveToken()
+}
+
 type APIKey struct {
 	apikey string
 }
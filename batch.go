@@ -0,0 +1,263 @@
+package hubspot
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// batchLimit is the maximum number of inputs HubSpot accepts in a batch request.
+	// The batch methods send larger inputs in chunks of this size.
+	batchLimit = 100
+)
+
+// BatchReadOption is a set of options to read objects in batch.
+type BatchReadOption struct {
+	// Properties are the properties to be returned.
+	Properties []string
+	// IDProperty is the unique property the IDs are values of, e.g. email for contacts. They are the object IDs when it is empty.
+	IDProperty string
+	Archived   bool
+}
+
+// BatchUpdateInput is the properties to update on an object.
+type BatchUpdateInput struct {
+	ID         string      `json:"id"`
+	Properties interface{} `json:"properties"`
+}
+
+// BatchUpsertInput is the properties to update on the object whose IDProperty has the value ID,
+// or to create an object with when there is none.
+type BatchUpsertInput struct {
+	ID         string      `json:"id"`
+	IDProperty string      `json:"idProperty"`
+	Properties interface{} `json:"properties"`
+}
+
+// BatchResponse is the response of the batch endpoints.
+// When the inputs have been sent in several chunks, it holds the results of all the chunks.
+type BatchResponse struct {
+	Status      string              `json:"status"`
+	Results     []*ResponseResource `json:"results"`
+	NumErrors   int                 `json:"numErrors,omitempty"`
+	Errors      []*BatchItemError   `json:"errors,omitempty"`
+	StartedAt   *HsTime             `json:"startedAt,omitempty"`
+	CompletedAt *HsTime             `json:"completedAt,omitempty"`
+}
+
+// BatchItemError is the error of some inputs of a batch request, reported by HubSpot with 207 Multi-Status.
+type BatchItemError struct {
+	Status      string              `json:"status,omitempty"`
+	Category    string              `json:"category,omitempty"`
+	SubCategory string              `json:"subCategory,omitempty"`
+	Message     string              `json:"message,omitempty"`
+	Context     map[string][]string `json:"context,omitempty"`
+}
+
+func (e *BatchItemError) Error() string {
+	return e.Message
+}
+
+// Is reports whether the error matches ErrNotFound or ErrValidation.
+func (e *BatchItemError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Category == ObjectNotFoundError
+	case ErrValidation:
+		return e.Category == ValidationError
+	}
+	return false
+}
+
+// IDs returns the IDs of the inputs which have failed, when HubSpot reports them.
+func (e *BatchItemError) IDs() []string {
+	return e.Context["ids"]
+}
+
+// BatchError is returned by the batch methods when HubSpot has failed some of the inputs, responding with 207 Multi-Status,
+// or when a chunk of inputs has failed entirely after the previous chunks have been processed.
+// The results of the other inputs are returned along with it in the BatchResponse.
+type BatchError struct {
+	// Errors are the errors of the inputs HubSpot has failed with 207 Multi-Status.
+	Errors []*BatchItemError
+	// Err is the error of the chunk which has failed entirely, e.g. with 500, if any.
+	// The inputs from FailedStart on, the ones of the chunk and of the chunks after it, have not been processed.
+	Err error
+	// FailedStart and FailedEnd are the indexes of the inputs of the failed chunk, FailedEnd being exclusive.
+	FailedStart, FailedEnd int
+}
+
+func (e *BatchError) Error() string {
+	if e.Err != nil {
+		msg := fmt.Sprintf("batch failed from input %d: %s", e.FailedStart, e.Err)
+		if len(e.Errors) > 0 {
+			msg += fmt.Sprintf(", after %d errors in the previous chunks", len(e.Errors))
+		}
+		return msg
+	}
+	if len(e.Errors) == 1 {
+		return fmt.Sprintf("batch partially failed: %s", e.Errors[0].Message)
+	}
+	return fmt.Sprintf("batch partially failed with %d errors, the first one: %s", len(e.Errors), e.Errors[0].Message)
+}
+
+// Unwrap returns the error of the failed chunk, if any.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+type batchID struct {
+	ID string `json:"id"`
+}
+
+type batchInputs struct {
+	Inputs interface{} `json:"inputs"`
+}
+
+// BatchRead reads objects by their IDs, or by the values of option.IDProperty.
+// The IDs are sent in chunks of 100.
+func (s *ObjectServiceOp) BatchRead(objectIDs []string, option *BatchReadOption) (*BatchResponse, error) {
+	return s.BatchReadWithContext(context.Background(), objectIDs, option)
+}
+
+// BatchReadWithContext reads objects in batch with the given context.
+func (s *ObjectServiceOp) BatchReadWithContext(ctx context.Context, objectIDs []string, option *BatchReadOption) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Objects.BatchRead", string(s.objectType))
+	if option == nil {
+		option = &BatchReadOption{}
+	}
+	properties := option.Properties
+	if properties == nil {
+		properties = []string{}
+	}
+	path := s.objectPath + "/batch/read"
+	if option.Archived {
+		path += "?archived=true"
+	}
+	return s.postBatch(ctx, path, len(objectIDs), func(start, end int) interface{} {
+		return struct {
+			Properties []string  `json:"properties"`
+			IDProperty string    `json:"idProperty,omitempty"`
+			Inputs     []batchID `json:"inputs"`
+		}{
+			Properties: properties,
+			IDProperty: option.IDProperty,
+			Inputs:     toBatchIDs(objectIDs[start:end]),
+		}
+	}, true)
+}
+
+// BatchCreate creates objects with the given properties.
+// The inputs are sent in chunks of 100.
+func (s *ObjectServiceOp) BatchCreate(properties []interface{}) (*BatchResponse, error) {
+	return s.BatchCreateWithContext(context.Background(), properties)
+}
+
+// BatchCreateWithContext creates objects in batch with the given context.
+func (s *ObjectServiceOp) BatchCreateWithContext(ctx context.Context, properties []interface{}) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Objects.BatchCreate", string(s.objectType))
+	inputs := make([]*RequestPayload, 0, len(properties))
+	for _, p := range properties {
+		inputs = append(inputs, &RequestPayload{Properties: p})
+	}
+	return s.postBatch(ctx, s.objectPath+"/batch/create", len(inputs), func(start, end int) interface{} {
+		return &batchInputs{Inputs: inputs[start:end]}
+	}, true)
+}
+
+// BatchUpdate updates objects.
+// The inputs are sent in chunks of 100.
+func (s *ObjectServiceOp) BatchUpdate(inputs []*BatchUpdateInput) (*BatchResponse, error) {
+	return s.BatchUpdateWithContext(context.Background(), inputs)
+}
+
+// BatchUpdateWithContext updates objects in batch with the given context.
+func (s *ObjectServiceOp) BatchUpdateWithContext(ctx context.Context, inputs []*BatchUpdateInput) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Objects.BatchUpdate", string(s.objectType))
+	return s.postBatch(ctx, s.objectPath+"/batch/update", len(inputs), func(start, end int) interface{} {
+		return &batchInputs{Inputs: inputs[start:end]}
+	}, true)
+}
+
+// BatchUpsert updates the objects identified by a unique property, or creates them when they do not exist.
+// The results tell whether each object has been created with ResponseResource.New.
+// The inputs are sent in chunks of 100.
+func (s *ObjectServiceOp) BatchUpsert(inputs []*BatchUpsertInput) (*BatchResponse, error) {
+	return s.BatchUpsertWithContext(context.Background(), inputs)
+}
+
+// BatchUpsertWithContext upserts objects in batch with the given context.
+func (s *ObjectServiceOp) BatchUpsertWithContext(ctx context.Context, inputs []*BatchUpsertInput) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Objects.BatchUpsert", string(s.objectType))
+	return s.postBatch(ctx, s.objectPath+"/batch/upsert", len(inputs), func(start, end int) interface{} {
+		return &batchInputs{Inputs: inputs[start:end]}
+	}, true)
+}
+
+// BatchArchive archives objects.
+// The IDs are sent in chunks of 100.
+func (s *ObjectServiceOp) BatchArchive(objectIDs []string) error {
+	return s.BatchArchiveWithContext(context.Background(), objectIDs)
+}
+
+// BatchArchiveWithContext archives objects in batch with the given context.
+func (s *ObjectServiceOp) BatchArchiveWithContext(ctx context.Context, objectIDs []string) error {
+	ctx = withOperation(ctx, "CRM.Objects.BatchArchive", string(s.objectType))
+	_, err := s.postBatch(ctx, s.objectPath+"/batch/archive", len(objectIDs), func(start, end int) interface{} {
+		return &batchInputs{Inputs: toBatchIDs(objectIDs[start:end])}
+	}, false)
+	return err
+}
+
+// postBatch posts n inputs to a batch endpoint in chunks of batchLimit inputs, and merges the responses.
+// chunk returns the request body of the inputs from start to end. withResults tells whether the endpoint responds with results.
+// When the first chunk fails entirely, its error is returned. When a later chunk does, a BatchError holding its error and
+// the errors of the inputs of the previous chunks is returned along with their results.
+// When HubSpot has failed some inputs, a BatchError is returned along with the results.
+func (s *ObjectServiceOp) postBatch(ctx context.Context, path string, n int, chunk func(start, end int) interface{}, withResults bool) (_ *BatchResponse, err error) {
+	ctx, endSpan := s.client.traceOperation(ctx)
+	defer func() { endSpan(err) }()
+
+	merged := &BatchResponse{Results: []*ResponseResource{}}
+	for start := 0; start < n; start += batchLimit {
+		end := start + batchLimit
+		if end > n {
+			end = n
+		}
+		var resource *BatchResponse
+		var v interface{}
+		if withResults {
+			resource = &BatchResponse{}
+			v = resource
+		}
+		if err := s.client.PostWithContext(ctx, path, chunk(start, end), v); err != nil {
+			if start == 0 {
+				return merged, err
+			}
+			return merged, &BatchError{Errors: merged.Errors, Err: err, FailedStart: start, FailedEnd: end}
+		}
+		if resource == nil {
+			continue
+		}
+		merged.Status = resource.Status
+		merged.Results = append(merged.Results, resource.Results...)
+		merged.NumErrors += resource.NumErrors
+		merged.Errors = append(merged.Errors, resource.Errors...)
+		if merged.StartedAt == nil {
+			merged.StartedAt = resource.StartedAt
+		}
+		merged.CompletedAt = resource.CompletedAt
+	}
+	if len(merged.Errors) > 0 {
+		return merged, &BatchError{Errors: merged.Errors}
+	}
+	return merged, nil
+}
+
+func toBatchIDs(ids []string) []batchID {
+	inputs := make([]batchID, 0, len(ids))
+	for _, id := range ids {
+		inputs = append(inputs, batchID{ID: id})
+	}
+	return inputs
+}
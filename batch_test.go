@@ -0,0 +1,232 @@
+package hubspot_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newBatchClient returns a client responding to the batch requests with respond, and recording their URLs and inputs.
+func newBatchClient(t *testing.T, respond func(n int, inputs []map[string]interface{}) (int, string), urls *[]string, inputs *[][]map[string]interface{}) *hubspot.Client {
+	t.Helper()
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(&http.Client{
+		Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Inputs []map[string]interface{} `json:"inputs"`
+			}
+			b, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(b, &body); err != nil {
+				t.Fatalf("request body is not JSON: %s", err)
+			}
+			*urls = append(*urls, req.URL.RequestURI())
+			*inputs = append(*inputs, body.Inputs)
+			status, response := respond(len(*urls), body.Inputs)
+			return hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: status, Body: []byte(response)}).Transport.RoundTrip(req)
+		}),
+	}))
+	return c
+}
+
+// echoResults responds to a batch read with a result per input.
+func echoResults(n int, inputs []map[string]interface{}) (int, string) {
+	results := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		results = append(results, fmt.Sprintf(`{"id":%q}`, input["id"]))
+	}
+	return http.StatusOK, `{"status":"COMPLETE","results":[` + strings.Join(results, ",") + `]}`
+}
+
+func TestObjectServiceOp_BatchChunks(t *testing.T) {
+	ids := make([]string, 0, 250)
+	for i := 1; i <= 250; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+
+	tests := []struct {
+		name       string
+		call       func(s hubspot.ObjectService) (*hubspot.BatchResponse, error)
+		respond    func(n int, inputs []map[string]interface{}) (int, string)
+		wantChunks []int
+		wantIDs    int
+		wantErr    error
+	}{
+		{
+			name: "Read in chunks of 100",
+			call: func(s hubspot.ObjectService) (*hubspot.BatchResponse, error) {
+				return s.BatchRead(ids, nil)
+			},
+			respond:    echoResults,
+			wantChunks: []int{100, 100, 50},
+			wantIDs:    250,
+		},
+		{
+			name: "Archive in chunks of 100",
+			call: func(s hubspot.ObjectService) (*hubspot.BatchResponse, error) {
+				return nil, s.BatchArchive(ids)
+			},
+			respond: func(n int, inputs []map[string]interface{}) (int, string) {
+				return http.StatusNoContent, ""
+			},
+			wantChunks: []int{100, 100, 50},
+		},
+		{
+			name: "No request without inputs",
+			call: func(s hubspot.ObjectService) (*hubspot.BatchResponse, error) {
+				return s.BatchRead(nil, nil)
+			},
+			respond: echoResults,
+		},
+		{
+			name: "Stop at a failed chunk",
+			call: func(s hubspot.ObjectService) (*hubspot.BatchResponse, error) {
+				return s.BatchRead(ids, nil)
+			},
+			respond: func(n int, inputs []map[string]interface{}) (int, string) {
+				if n == 2 {
+					return http.StatusBadRequest, `{"status":"error","message":"Invalid input","category":"VALIDATION_ERROR"}`
+				}
+				return echoResults(n, inputs)
+			},
+			wantChunks: []int{100, 100},
+			wantIDs:    100,
+			wantErr:    hubspot.ErrValidation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var urls []string
+			var inputs [][]map[string]interface{}
+			c := newBatchClient(t, tt.respond, &urls, &inputs)
+
+			res, err := tt.call(c.CRM.Objects(hubspot.ObjectTypeContact))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error mismatch: want %v got %v", tt.wantErr, err)
+			}
+			var chunks []int
+			for _, in := range inputs {
+				chunks = append(chunks, len(in))
+			}
+			if diff := cmp.Diff(tt.wantChunks, chunks); diff != "" {
+				t.Errorf("chunks mismatch (-want +got):%s", diff)
+			}
+			if res != nil && len(res.Results) != tt.wantIDs {
+				t.Errorf("results mismatch: want %d got %d", tt.wantIDs, len(res.Results))
+			}
+		})
+	}
+}
+
+func TestObjectServiceOp_BatchPartialFailure(t *testing.T) {
+	var urls []string
+	var inputs [][]map[string]interface{}
+	c := newBatchClient(t, func(n int, inputs []map[string]interface{}) (int, string) {
+		return http.StatusMultiStatus, `{
+			"status":"COMPLETE",
+			"results":[{"id":"1"}],
+			"numErrors":1,
+			"errors":[{"status":"error","category":"OBJECT_NOT_FOUND","message":"Could not get some CONTACT objects, they may be deleted or not exist.","context":{"ids":["2"]}}]
+		}`
+	}, &urls, &inputs)
+
+	res, err := c.CRM.Contact.BatchRead([]string{"1", "2"}, &hubspot.BatchReadOption{Properties: []string{"email"}, Archived: true})
+
+	var batchErr *hubspot.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("error mismatch: want *hubspot.BatchError got %v", err)
+	}
+	if len(batchErr.Errors) != 1 || !errors.Is(batchErr.Errors[0], hubspot.ErrNotFound) {
+		t.Errorf("item errors mismatch: want an ErrNotFound got %v", batchErr.Errors)
+	}
+	if diff := cmp.Diff([]string{"2"}, batchErr.Errors[0].IDs()); diff != "" {
+		t.Errorf("failed IDs mismatch (-want +got):%s", diff)
+	}
+	want := []*hubspot.ResponseResource{{ID: "1"}}
+	if diff := cmp.Diff(want, res.Results); diff != "" {
+		t.Errorf("results mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff([]string{"/crm/v3/objects/contacts/batch/read?archived=true"}, urls); diff != "" {
+		t.Errorf("URL mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestObjectServiceOp_BatchChunkFailure(t *testing.T) {
+	ids := make([]string, 0, 150)
+	for i := 1; i <= 150; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+	var urls []string
+	var inputs [][]map[string]interface{}
+	c := newBatchClient(t, func(n int, inputs []map[string]interface{}) (int, string) {
+		if n == 2 {
+			return http.StatusInternalServerError, `{"status":"error","message":"internal error","category":"INTERNAL_ERROR"}`
+		}
+		return http.StatusMultiStatus, `{
+			"status":"COMPLETE",
+			"results":[{"id":"1"}],
+			"numErrors":1,
+			"errors":[{"status":"error","category":"OBJECT_NOT_FOUND","message":"Could not get some CONTACT objects, they may be deleted or not exist.","context":{"ids":["2"]}}]
+		}`
+	}, &urls, &inputs)
+
+	res, err := c.CRM.Contact.BatchRead(ids, nil)
+
+	var batchErr *hubspot.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("error mismatch: want *hubspot.BatchError got %v", err)
+	}
+	if len(batchErr.Errors) != 1 || !errors.Is(batchErr.Errors[0], hubspot.ErrNotFound) {
+		t.Errorf("item errors of the first chunk mismatch: want an ErrNotFound got %v", batchErr.Errors)
+	}
+	var apiErr *hubspot.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusInternalServerError {
+		t.Errorf("error of the failed chunk mismatch: want 500 got %v", batchErr.Err)
+	}
+	if batchErr.FailedStart != 100 || batchErr.FailedEnd != 150 {
+		t.Errorf("failed inputs mismatch: want 100 to 150 got %d to %d", batchErr.FailedStart, batchErr.FailedEnd)
+	}
+	want := "batch failed from input 100: 500: internal error, after 1 errors in the previous chunks"
+	if err.Error() != want {
+		t.Errorf("Error() mismatch: want %s got %s", want, err.Error())
+	}
+	if diff := cmp.Diff([]*hubspot.ResponseResource{{ID: "1"}}, res.Results); diff != "" {
+		t.Errorf("results mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestObjectServiceOp_BatchUpsert(t *testing.T) {
+	var urls []string
+	var inputs [][]map[string]interface{}
+	c := newBatchClient(t, func(n int, inputs []map[string]interface{}) (int, string) {
+		return http.StatusOK, `{"status":"COMPLETE","results":[{"id":"1","new":false},{"id":"2","new":true}]}`
+	}, &urls, &inputs)
+
+	res, err := c.CRM.Company.BatchUpsert([]*hubspot.BatchUpsertInput{
+		{ID: "example.com", IDProperty: "domain", Properties: map[string]string{"name": "Example"}},
+		{ID: "example.org", IDProperty: "domain", Properties: map[string]string{"name": "Example Org"}},
+	})
+	if err != nil {
+		t.Fatalf("BatchUpsert() error: %s", err)
+	}
+
+	wantInputs := [][]map[string]interface{}{{
+		{"id": "example.com", "idProperty": "domain", "properties": map[string]interface{}{"name": "Example"}},
+		{"id": "example.org", "idProperty": "domain", "properties": map[string]interface{}{"name": "Example Org"}},
+	}}
+	if diff := cmp.Diff(wantInputs, inputs); diff != "" {
+		t.Errorf("inputs mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff([]string{"/crm/v3/objects/companies/batch/upsert"}, urls); diff != "" {
+		t.Errorf("URL mismatch (-want +got):%s", diff)
+	}
+	want := []*hubspot.ResponseResource{{ID: "1"}, {ID: "2", New: true}}
+	if diff := cmp.Diff(want, res.Results); diff != "" {
+		t.Errorf("results mismatch (-want +got):%s", diff)
+	}
+}
@@ -1,5 +1,7 @@
 package hubspot
 
+import "context"
+
 const (
 	companyBasePath = "companies"
 )
@@ -10,13 +12,34 @@ const (
 // Reference: https://developers.hubspot.com/docs/api/crm/companies
 type CompanyService interface {
 	Get(companyID string, company interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	GetWithContext(ctx context.Context, companyID string, company interface{}, option *RequestQueryOption) (*ResponseResource, error)
 	Create(company interface{}) (*ResponseResource, error)
+	CreateWithContext(ctx context.Context, company interface{}) (*ResponseResource, error)
+	CreateOrGet(company interface{}, option *RequestQueryOption) (*ResponseResource, bool, error)
+	CreateOrGetWithContext(ctx context.Context, company interface{}, option *RequestQueryOption) (*ResponseResource, bool, error)
 	Update(companyID string, company interface{}) (*ResponseResource, error)
+	UpdateWithContext(ctx context.Context, companyID string, company interface{}) (*ResponseResource, error)
 	Delete(companyID string) error
+	DeleteWithContext(ctx context.Context, companyID string) error
 	AssociateAnotherObj(companyID string, conf *AssociationConfig) (*ResponseResource, error)
+	AssociateAnotherObjWithContext(ctx context.Context, companyID string, conf *AssociationConfig) (*ResponseResource, error)
 	SearchByDomain(domain string) (*CompanySearchResponse, error)
+	SearchByDomainWithContext(ctx context.Context, domain string) (*CompanySearchResponse, error)
 	SearchByName(name string) (*CompanySearchResponse, error)
+	SearchByNameWithContext(ctx context.Context, name string) (*CompanySearchResponse, error)
 	Search(req *CompanySearchRequest) (*CompanySearchResponse, error)
+	SearchWithContext(ctx context.Context, req *CompanySearchRequest) (*CompanySearchResponse, error)
+	SearchAll(req *CompanySearchRequest, option *SearchPartitionOption) *Pager
+	BatchRead(companyIDs []string, option *BatchReadOption) (*BatchResponse, error)
+	BatchReadWithContext(ctx context.Context, companyIDs []string, option *BatchReadOption) (*BatchResponse, error)
+	BatchCreate(companys []interface{}) (*BatchResponse, error)
+	BatchCreateWithContext(ctx context.Context, companys []interface{}) (*BatchResponse, error)
+	BatchUpdate(inputs []*BatchUpdateInput) (*BatchResponse, error)
+	BatchUpdateWithContext(ctx context.Context, inputs []*BatchUpdateInput) (*BatchResponse, error)
+	BatchUpsert(inputs []*BatchUpsertInput) (*BatchResponse, error)
+	BatchUpsertWithContext(ctx context.Context, inputs []*BatchUpsertInput) (*BatchResponse, error)
+	BatchArchive(companyIDs []string) error
+	BatchArchiveWithContext(ctx context.Context, companyIDs []string) error
 }
 
 // CompanyServiceOp handles communication with the product related methods of the HubSpot API.
@@ -33,8 +56,14 @@ var _ CompanyService = (*CompanyServiceOp)(nil)
 // If you specify a non-existent field, it will be ignored.
 // e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
 func (s *CompanyServiceOp) Get(companyID string, company interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	return s.GetWithContext(context.Background(), companyID, company, option)
+}
+
+// GetWithContext gets a Company with the given context.
+func (s *CompanyServiceOp) GetWithContext(ctx context.Context, companyID string, company interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Company.Get", companyBasePath)
 	resource := &ResponseResource{Properties: company}
-	if err := s.client.Get(s.companyPath+"/"+companyID, resource, option.setupProperties(defaultCompanyFields)); err != nil {
+	if err := s.client.GetWithContext(ctx, s.companyPath+"/"+companyID, resource, option.setupProperties(defaultCompanyFields)); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -44,21 +73,62 @@ func (s *CompanyServiceOp) Get(companyID string, company interface{}, option *Re
 // In order to bind the created content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Company in your own structure.
 func (s *CompanyServiceOp) Create(company interface{}) (*ResponseResource, error) {
+	return s.CreateWithContext(context.Background(), company)
+}
+
+// CreateWithContext creates a new company with the given context.
+func (s *CompanyServiceOp) CreateWithContext(ctx context.Context, company interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Company.Create", companyBasePath)
 	req := &RequestPayload{Properties: company}
 	resource := &ResponseResource{Properties: company}
-	if err := s.client.Post(s.companyPath, req, resource); err != nil {
+	if err := s.client.PostWithContext(ctx, s.companyPath, req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
 }
 
+// CreateOrGet creates a new company, or gets the existing one when HubSpot responds that it already exists.
+// The returned bool reports whether the company has been created.
+// The option is used to get the existing company, as with Get.
+func (s *CompanyServiceOp) CreateOrGet(company interface{}, option *RequestQueryOption) (*ResponseResource, bool, error) {
+	return s.CreateOrGetWithContext(context.Background(), company, option)
+}
+
+// CreateOrGetWithContext creates a new company, or gets the existing one, with the given context.
+func (s *CompanyServiceOp) CreateOrGetWithContext(ctx context.Context, company interface{}, option *RequestQueryOption) (_ *ResponseResource, _ bool, err error) {
+	ctx = withOperation(ctx, "CRM.Company.CreateOrGet", companyBasePath)
+	ctx, endSpan := s.client.traceOperation(ctx)
+	defer func() { endSpan(err) }()
+
+	resource, err := s.CreateWithContext(ctx, company)
+	if err == nil {
+		return resource, true, nil
+	}
+	id, ok := existingID(err)
+	if !ok {
+		return nil, false, err
+	}
+	// The existing record is decoded into a new value, not to mix it with the values to be created.
+	resource, err = s.GetWithContext(ctx, id, newPropertiesLike(company), option)
+	if err != nil {
+		return nil, false, err
+	}
+	return resource, false, nil
+}
+
 // Update updates a company.
 // In order to bind the updated content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Company in your own structure.
 func (s *CompanyServiceOp) Update(companyID string, company interface{}) (*ResponseResource, error) {
+	return s.UpdateWithContext(context.Background(), companyID, company)
+}
+
+// UpdateWithContext updates a company with the given context.
+func (s *CompanyServiceOp) UpdateWithContext(ctx context.Context, companyID string, company interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Company.Update", companyBasePath)
 	req := &RequestPayload{Properties: company}
 	resource := &ResponseResource{Properties: company}
-	if err := s.client.Patch(s.companyPath+"/"+companyID, req, resource); err != nil {
+	if err := s.client.PatchWithContext(ctx, s.companyPath+"/"+companyID, req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -66,14 +136,26 @@ func (s *CompanyServiceOp) Update(companyID string, company interface{}) (*Respo
 
 // Delete deletes a company.
 func (s *CompanyServiceOp) Delete(companyID string) error {
-	return s.client.Delete(s.companyPath+"/"+companyID, nil)
+	return s.DeleteWithContext(context.Background(), companyID)
+}
+
+// DeleteWithContext deletes a company with the given context.
+func (s *CompanyServiceOp) DeleteWithContext(ctx context.Context, companyID string) error {
+	ctx = withOperation(ctx, "CRM.Company.Delete", companyBasePath)
+	return s.client.DeleteWithContext(ctx, s.companyPath+"/"+companyID, nil)
 }
 
 // AssociateAnotherObj associates Company with another HubSpot objects.
 // If you want to associate a custom object, please use a defined value in HubSpot.
 func (s *CompanyServiceOp) AssociateAnotherObj(companyID string, conf *AssociationConfig) (*ResponseResource, error) {
+	return s.AssociateAnotherObjWithContext(context.Background(), companyID, conf)
+}
+
+// AssociateAnotherObjWithContext associates Company with another HubSpot objects using the given context.
+func (s *CompanyServiceOp) AssociateAnotherObjWithContext(ctx context.Context, companyID string, conf *AssociationConfig) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Company.AssociateAnotherObj", companyBasePath)
 	resource := &ResponseResource{Properties: &Company{}}
-	if err := s.client.Put(s.companyPath+"/"+companyID+"/"+conf.makeAssociationPath(), nil, resource); err != nil {
+	if err := s.client.PutWithContext(ctx, s.companyPath+"/"+companyID+"/"+conf.makeAssociationPath(), nil, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -87,6 +169,7 @@ type CompanySearchRequest struct {
 type CompanySearchResponse struct {
 	Total   int64           `json:"total"`
 	Results []CompanyResult `json:"results"`
+	Paging  *Paging         `json:"paging,omitempty"`
 }
 
 type CompanyResult struct {
@@ -100,6 +183,12 @@ type CompanyResult struct {
 // SearchByDomain searches for a company by domain.
 // EXPERIMENTAL: This method is experimental and the interface may change in the future to support custom properties.
 func (s *CompanyServiceOp) SearchByDomain(domain string) (*CompanySearchResponse, error) {
+	return s.SearchByDomainWithContext(context.Background(), domain)
+}
+
+// SearchByDomainWithContext searches for a company by domain with the given context.
+func (s *CompanyServiceOp) SearchByDomainWithContext(ctx context.Context, domain string) (*CompanySearchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Company.SearchByDomain", companyBasePath)
 	req := &CompanySearchRequest{
 		SearchOptions: SearchOptions{
 			FilterGroups: []FilterGroup{
@@ -115,12 +204,18 @@ func (s *CompanyServiceOp) SearchByDomain(domain string) (*CompanySearchResponse
 			},
 		},
 	}
-	return s.Search(req)
+	return s.SearchWithContext(ctx, req)
 }
 
 // SearchByName searches for a company by name.
 // EXPERIMENTAL: This method is experimental and the interface may change in the future to support custom properties.
 func (s *CompanyServiceOp) SearchByName(name string) (*CompanySearchResponse, error) {
+	return s.SearchByNameWithContext(context.Background(), name)
+}
+
+// SearchByNameWithContext searches for a company by name with the given context.
+func (s *CompanyServiceOp) SearchByNameWithContext(ctx context.Context, name string) (*CompanySearchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Company.SearchByName", companyBasePath)
 	req := &CompanySearchRequest{
 		SearchOptions: SearchOptions{
 			FilterGroups: []FilterGroup{
@@ -136,15 +231,101 @@ func (s *CompanyServiceOp) SearchByName(name string) (*CompanySearchResponse, er
 			},
 		},
 	}
-	return s.Search(req)
+	return s.SearchWithContext(ctx, req)
 }
 
 // Search searches for a company by any given property filters, including custom properties.
 // EXPERIMENTAL: This method is experimental and the interface may change in the future to support custom properties.
 func (s *CompanyServiceOp) Search(req *CompanySearchRequest) (*CompanySearchResponse, error) {
+	return s.SearchWithContext(context.Background(), req)
+}
+
+// SearchWithContext is Search with the given context.
+func (s *CompanyServiceOp) SearchWithContext(ctx context.Context, req *CompanySearchRequest) (*CompanySearchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Company.Search", companyBasePath)
 	resource := &CompanySearchResponse{}
-	if err := s.client.Post(s.companyPath+"/search", req, resource); err != nil {
+	if err := s.client.PostWithContext(ctx, s.companyPath+"/search", req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
 }
+
+// SearchAll returns a pager searching for companies beyond the 10,000 results HubSpot returns for a search,
+// by partitioning the search by option.Property. See NewPartitionedSearchPager.
+func (s *CompanyServiceOp) SearchAll(req *CompanySearchRequest, option *SearchPartitionOption) *Pager {
+	var opts SearchOptions
+	if req != nil {
+		opts = req.SearchOptions
+	}
+	objects := s.objects()
+	p := newPartitionedSearchPager(func(ctx context.Context, opts *SearchOptions) (*ObjectList, error) {
+		ctx = withOperation(ctx, "CRM.Company.Search", companyBasePath)
+		return objects.SearchWithContext(ctx, &ObjectSearchRequest{SearchOptions: *opts})
+	}, opts, option)
+	p.trace = s.client.operationTracer("CRM.Company.Search", companyBasePath)
+	return p
+}
+
+// BatchRead reads companies by their IDs, or by the values of option.IDProperty.
+// The IDs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *CompanyServiceOp) BatchRead(companyIDs []string, option *BatchReadOption) (*BatchResponse, error) {
+	return s.BatchReadWithContext(context.Background(), companyIDs, option)
+}
+
+// BatchReadWithContext reads companies in batch with the given context.
+func (s *CompanyServiceOp) BatchReadWithContext(ctx context.Context, companyIDs []string, option *BatchReadOption) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Company.BatchRead", companyBasePath)
+	return s.objects().BatchReadWithContext(ctx, companyIDs, option)
+}
+
+// BatchCreate creates companies in batch.
+// The inputs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *CompanyServiceOp) BatchCreate(companys []interface{}) (*BatchResponse, error) {
+	return s.BatchCreateWithContext(context.Background(), companys)
+}
+
+// BatchCreateWithContext creates companies in batch with the given context.
+func (s *CompanyServiceOp) BatchCreateWithContext(ctx context.Context, companys []interface{}) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Company.BatchCreate", companyBasePath)
+	return s.objects().BatchCreateWithContext(ctx, companys)
+}
+
+// BatchUpdate updates companies in batch.
+// The inputs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *CompanyServiceOp) BatchUpdate(inputs []*BatchUpdateInput) (*BatchResponse, error) {
+	return s.BatchUpdateWithContext(context.Background(), inputs)
+}
+
+// BatchUpdateWithContext updates companies in batch with the given context.
+func (s *CompanyServiceOp) BatchUpdateWithContext(ctx context.Context, inputs []*BatchUpdateInput) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Company.BatchUpdate", companyBasePath)
+	return s.objects().BatchUpdateWithContext(ctx, inputs)
+}
+
+// BatchUpsert updates companies identified by a unique property, or creates them when they do not exist.
+// The inputs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *CompanyServiceOp) BatchUpsert(inputs []*BatchUpsertInput) (*BatchResponse, error) {
+	return s.BatchUpsertWithContext(context.Background(), inputs)
+}
+
+// BatchUpsertWithContext upserts companies in batch with the given context.
+func (s *CompanyServiceOp) BatchUpsertWithContext(ctx context.Context, inputs []*BatchUpsertInput) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Company.BatchUpsert", companyBasePath)
+	return s.objects().BatchUpsertWithContext(ctx, inputs)
+}
+
+// BatchArchive archives companies in batch.
+// The IDs are sent in chunks of 100.
+func (s *CompanyServiceOp) BatchArchive(companyIDs []string) error {
+	return s.BatchArchiveWithContext(context.Background(), companyIDs)
+}
+
+// BatchArchiveWithContext archives companies in batch with the given context.
+func (s *CompanyServiceOp) BatchArchiveWithContext(ctx context.Context, companyIDs []string) error {
+	ctx = withOperation(ctx, "CRM.Company.BatchArchive", companyBasePath)
+	return s.objects().BatchArchiveWithContext(ctx, companyIDs)
+}
+
+func (s *CompanyServiceOp) objects() *ObjectServiceOp {
+	return &ObjectServiceOp{objectType: companyBasePath, objectPath: s.companyPath, client: s.client}
+}
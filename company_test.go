@@ -105,6 +105,98 @@ func TestCompanyServiceOp_Create(t *testing.T) {
 	}
 }
 
+func TestCompanyServiceOp_CreateOrGet(t *testing.T) {
+	conflict := []byte(`{"status":"error","message":"Company already exists. Existing ID: 512","category":"CONFLICT"}`)
+	existing := []byte(`{"id":"512","properties":{"domain":"biglytics.net","name":"Biglytics Inc."},"archived":false}`)
+
+	tests := []struct {
+		name        string
+		responses   []*hubspot.MockConfig
+		want        *hubspot.ResponseResource
+		wantCreated bool
+		wantPaths   []string
+		wantErr     error
+	}{
+		{
+			name: "Successfully create a company",
+			responses: []*hubspot.MockConfig{
+				{Status: http.StatusCreated, Body: []byte(`{"id":"company001","properties":{"domain":"biglytics.net"},"archived":false}`)},
+			},
+			want: &hubspot.ResponseResource{
+				ID: "company001",
+				Properties: &hubspot.Company{
+					Domain: hubspot.NewString("biglytics.net"),
+					Name:   hubspot.NewString("Biglytics"),
+					City:   hubspot.NewString("Cambridge"),
+				},
+			},
+			wantCreated: true,
+			wantPaths:   []string{"POST /crm/v3/objects/companies"},
+		},
+		{
+			name: "Get the existing company",
+			responses: []*hubspot.MockConfig{
+				{Status: http.StatusConflict, Body: conflict},
+				{Status: http.StatusOK, Body: existing},
+			},
+			want: &hubspot.ResponseResource{
+				ID: "512",
+				Properties: &hubspot.Company{
+					Domain: hubspot.NewString("biglytics.net"),
+					Name:   hubspot.NewString("Biglytics Inc."),
+				},
+			},
+			wantCreated: false,
+			wantPaths:   []string{"POST /crm/v3/objects/companies", "GET /crm/v3/objects/companies/512"},
+		},
+		{
+			name: "Received invalid request",
+			responses: []*hubspot.MockConfig{
+				{Status: http.StatusBadRequest, Body: []byte(`{"message":"Property values were not valid","category":"VALIDATION_ERROR"}`)},
+			},
+			wantPaths: []string{"POST /crm/v3/objects/companies"},
+			wantErr: &hubspot.APIError{
+				HTTPStatusCode: http.StatusBadRequest,
+				Message:        "Property values were not valid",
+				Category:       hubspot.ValidationError,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(&http.Client{
+				Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+					conf := tt.responses[len(paths)]
+					paths = append(paths, req.Method+" "+req.URL.Path)
+					return hubspot.NewMockHTTPClient(conf).Transport.RoundTrip(req)
+				}),
+			}))
+
+			// The existing company has no city, which must not be taken from the input.
+			input := &hubspot.Company{
+				Domain: hubspot.NewString("biglytics.net"),
+				Name:   hubspot.NewString("Biglytics"),
+				City:   hubspot.NewString("Cambridge"),
+			}
+			got, created, err := c.CRM.Company.CreateOrGet(input, nil)
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("CreateOrGet() error mismatch: want %s got %s", tt.wantErr, err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmpTimeOption); diff != "" {
+				t.Errorf("CreateOrGet() response mismatch (-want +got):%s", diff)
+			}
+			if tt.wantCreated != created {
+				t.Errorf("CreateOrGet() created mismatch: want %t got %t", tt.wantCreated, created)
+			}
+			if diff := cmp.Diff(tt.wantPaths, paths); diff != "" {
+				t.Errorf("request paths mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
 func TestCompanyServiceOp_Update(t *testing.T) {
 	company := &hubspot.Company{
 		City:     hubspot.NewString("Cambridge"),
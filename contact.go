@@ -1,5 +1,7 @@
 package hubspot
 
+import "context"
+
 const (
 	contactBasePath = "contacts"
 )
@@ -10,12 +12,32 @@ const (
 // Reference: https://developers.hubspot.com/docs/api/crm/contacts
 type ContactService interface {
 	Get(contactID string, contact interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	GetWithContext(ctx context.Context, contactID string, contact interface{}, option *RequestQueryOption) (*ResponseResource, error)
 	Create(contact interface{}) (*ResponseResource, error)
+	CreateWithContext(ctx context.Context, contact interface{}) (*ResponseResource, error)
+	CreateOrGet(contact interface{}, option *RequestQueryOption) (*ResponseResource, bool, error)
+	CreateOrGetWithContext(ctx context.Context, contact interface{}, option *RequestQueryOption) (*ResponseResource, bool, error)
 	Update(contactID string, contact interface{}) (*ResponseResource, error)
+	UpdateWithContext(ctx context.Context, contactID string, contact interface{}) (*ResponseResource, error)
 	Delete(contactID string) error
+	DeleteWithContext(ctx context.Context, contactID string) error
 	AssociateAnotherObj(contactID string, conf *AssociationConfig) (*ResponseResource, error)
+	AssociateAnotherObjWithContext(ctx context.Context, contactID string, conf *AssociationConfig) (*ResponseResource, error)
 	SearchByEmail(email string) (*ContactSearchResponse, error)
+	SearchByEmailWithContext(ctx context.Context, email string) (*ContactSearchResponse, error)
 	Search(req *ContactSearchRequest) (*ContactSearchResponse, error)
+	SearchWithContext(ctx context.Context, req *ContactSearchRequest) (*ContactSearchResponse, error)
+	SearchAll(req *ContactSearchRequest, option *SearchPartitionOption) *Pager
+	BatchRead(contactIDs []string, option *BatchReadOption) (*BatchResponse, error)
+	BatchReadWithContext(ctx context.Context, contactIDs []string, option *BatchReadOption) (*BatchResponse, error)
+	BatchCreate(contacts []interface{}) (*BatchResponse, error)
+	BatchCreateWithContext(ctx context.Context, contacts []interface{}) (*BatchResponse, error)
+	BatchUpdate(inputs []*BatchUpdateInput) (*BatchResponse, error)
+	BatchUpdateWithContext(ctx context.Context, inputs []*BatchUpdateInput) (*BatchResponse, error)
+	BatchUpsert(inputs []*BatchUpsertInput) (*BatchResponse, error)
+	BatchUpsertWithContext(ctx context.Context, inputs []*BatchUpsertInput) (*BatchResponse, error)
+	BatchArchive(contactIDs []string) error
+	BatchArchiveWithContext(ctx context.Context, contactIDs []string) error
 }
 
 // ContactServiceOp handles communication with the product related methods of the HubSpot API.
@@ -35,6 +57,7 @@ type ContactSearchRequest struct {
 type ContactSearchResponse struct {
 	Total   int64           `json:"total"`
 	Results []ContactResult `json:"results"`
+	Paging  *Paging         `json:"paging,omitempty"`
 }
 
 type ContactResult struct {
@@ -342,8 +365,14 @@ var defaultContactFields = []string{
 // If you specify a non-existent field, it will be ignored.
 // e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
 func (s *ContactServiceOp) Get(contactID string, contact interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	return s.GetWithContext(context.Background(), contactID, contact, option)
+}
+
+// GetWithContext gets a contact with the given context.
+func (s *ContactServiceOp) GetWithContext(ctx context.Context, contactID string, contact interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Contact.Get", contactBasePath)
 	resource := &ResponseResource{Properties: contact}
-	if err := s.client.Get(s.contactPath+"/"+contactID, resource, option.setupProperties(defaultContactFields)); err != nil {
+	if err := s.client.GetWithContext(ctx, s.contactPath+"/"+contactID, resource, option.setupProperties(defaultContactFields)); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -353,21 +382,62 @@ func (s *ContactServiceOp) Get(contactID string, contact interface{}, option *Re
 // In order to bind the created content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Contact in your own structure.
 func (s *ContactServiceOp) Create(contact interface{}) (*ResponseResource, error) {
+	return s.CreateWithContext(context.Background(), contact)
+}
+
+// CreateWithContext creates a new contact with the given context.
+func (s *ContactServiceOp) CreateWithContext(ctx context.Context, contact interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Contact.Create", contactBasePath)
 	req := &RequestPayload{Properties: contact}
 	resource := &ResponseResource{Properties: contact}
-	if err := s.client.Post(s.contactPath, req, resource); err != nil {
+	if err := s.client.PostWithContext(ctx, s.contactPath, req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
 }
 
+// CreateOrGet creates a new contact, or gets the existing one when HubSpot responds that it already exists.
+// The returned bool reports whether the contact has been created.
+// The option is used to get the existing contact, as with Get.
+func (s *ContactServiceOp) CreateOrGet(contact interface{}, option *RequestQueryOption) (*ResponseResource, bool, error) {
+	return s.CreateOrGetWithContext(context.Background(), contact, option)
+}
+
+// CreateOrGetWithContext creates a new contact, or gets the existing one, with the given context.
+func (s *ContactServiceOp) CreateOrGetWithContext(ctx context.Context, contact interface{}, option *RequestQueryOption) (_ *ResponseResource, _ bool, err error) {
+	ctx = withOperation(ctx, "CRM.Contact.CreateOrGet", contactBasePath)
+	ctx, endSpan := s.client.traceOperation(ctx)
+	defer func() { endSpan(err) }()
+
+	resource, err := s.CreateWithContext(ctx, contact)
+	if err == nil {
+		return resource, true, nil
+	}
+	id, ok := existingID(err)
+	if !ok {
+		return nil, false, err
+	}
+	// The existing record is decoded into a new value, not to mix it with the values to be created.
+	resource, err = s.GetWithContext(ctx, id, newPropertiesLike(contact), option)
+	if err != nil {
+		return nil, false, err
+	}
+	return resource, false, nil
+}
+
 // Update updates a contact.
 // In order to bind the updated content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Contact in your own structure.
 func (s *ContactServiceOp) Update(contactID string, contact interface{}) (*ResponseResource, error) {
+	return s.UpdateWithContext(context.Background(), contactID, contact)
+}
+
+// UpdateWithContext updates a contact with the given context.
+func (s *ContactServiceOp) UpdateWithContext(ctx context.Context, contactID string, contact interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Contact.Update", contactBasePath)
 	req := &RequestPayload{Properties: contact}
 	resource := &ResponseResource{Properties: contact}
-	if err := s.client.Patch(s.contactPath+"/"+contactID, req, resource); err != nil {
+	if err := s.client.PatchWithContext(ctx, s.contactPath+"/"+contactID, req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -375,14 +445,26 @@ func (s *ContactServiceOp) Update(contactID string, contact interface{}) (*Respo
 
 // Delete deletes a contact.
 func (s *ContactServiceOp) Delete(contactID string) error {
-	return s.client.Delete(s.contactPath+"/"+contactID, nil)
+	return s.DeleteWithContext(context.Background(), contactID)
+}
+
+// DeleteWithContext deletes a contact with the given context.
+func (s *ContactServiceOp) DeleteWithContext(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, "CRM.Contact.Delete", contactBasePath)
+	return s.client.DeleteWithContext(ctx, s.contactPath+"/"+contactID, nil)
 }
 
 // AssociateAnotherObj associates Contact with another HubSpot objects.
 // If you want to associate a custom object, please use a defined value in HubSpot.
 func (s *ContactServiceOp) AssociateAnotherObj(contactID string, conf *AssociationConfig) (*ResponseResource, error) {
+	return s.AssociateAnotherObjWithContext(context.Background(), contactID, conf)
+}
+
+// AssociateAnotherObjWithContext associates Contact with another HubSpot objects using the given context.
+func (s *ContactServiceOp) AssociateAnotherObjWithContext(ctx context.Context, contactID string, conf *AssociationConfig) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Contact.AssociateAnotherObj", contactBasePath)
 	resource := &ResponseResource{Properties: &Contact{}}
-	if err := s.client.Put(s.contactPath+"/"+contactID+"/"+conf.makeAssociationPath(), nil, resource); err != nil {
+	if err := s.client.PutWithContext(ctx, s.contactPath+"/"+contactID+"/"+conf.makeAssociationPath(), nil, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -391,6 +473,12 @@ func (s *ContactServiceOp) AssociateAnotherObj(contactID string, conf *Associati
 // SearchByEmail searches for a contact by email.
 // EXPERIMENTAL: This method is experimental and the interface may change in the future to support custom properties.
 func (s *ContactServiceOp) SearchByEmail(email string) (*ContactSearchResponse, error) {
+	return s.SearchByEmailWithContext(context.Background(), email)
+}
+
+// SearchByEmailWithContext searches for a contact by email with the given context.
+func (s *ContactServiceOp) SearchByEmailWithContext(ctx context.Context, email string) (*ContactSearchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Contact.SearchByEmail", contactBasePath)
 	req := &ContactSearchRequest{
 		SearchOptions: SearchOptions{
 			FilterGroups: []FilterGroup{
@@ -406,15 +494,101 @@ func (s *ContactServiceOp) SearchByEmail(email string) (*ContactSearchResponse,
 			},
 		},
 	}
-	return s.Search(req)
+	return s.SearchWithContext(ctx, req)
 }
 
 // Search searches for a contact by any given property filters, including custom properties.
 // EXPERIMENTAL: This method is experimental and the interface may change in the future to support custom properties.
 func (s *ContactServiceOp) Search(req *ContactSearchRequest) (*ContactSearchResponse, error) {
+	return s.SearchWithContext(context.Background(), req)
+}
+
+// SearchWithContext is Search with the given context.
+func (s *ContactServiceOp) SearchWithContext(ctx context.Context, req *ContactSearchRequest) (*ContactSearchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Contact.Search", contactBasePath)
 	resource := &ContactSearchResponse{}
-	if err := s.client.Post(s.contactPath+"/search", req, resource); err != nil {
+	if err := s.client.PostWithContext(ctx, s.contactPath+"/search", req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
 }
+
+// SearchAll returns a pager searching for contacts beyond the 10,000 results HubSpot returns for a search,
+// by partitioning the search by option.Property. See NewPartitionedSearchPager.
+func (s *ContactServiceOp) SearchAll(req *ContactSearchRequest, option *SearchPartitionOption) *Pager {
+	var opts SearchOptions
+	if req != nil {
+		opts = req.SearchOptions
+	}
+	objects := s.objects()
+	p := newPartitionedSearchPager(func(ctx context.Context, opts *SearchOptions) (*ObjectList, error) {
+		ctx = withOperation(ctx, "CRM.Contact.Search", contactBasePath)
+		return objects.SearchWithContext(ctx, &ObjectSearchRequest{SearchOptions: *opts})
+	}, opts, option)
+	p.trace = s.client.operationTracer("CRM.Contact.Search", contactBasePath)
+	return p
+}
+
+// BatchRead reads contacts by their IDs, or by the values of option.IDProperty.
+// The IDs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *ContactServiceOp) BatchRead(contactIDs []string, option *BatchReadOption) (*BatchResponse, error) {
+	return s.BatchReadWithContext(context.Background(), contactIDs, option)
+}
+
+// BatchReadWithContext reads contacts in batch with the given context.
+func (s *ContactServiceOp) BatchReadWithContext(ctx context.Context, contactIDs []string, option *BatchReadOption) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Contact.BatchRead", contactBasePath)
+	return s.objects().BatchReadWithContext(ctx, contactIDs, option)
+}
+
+// BatchCreate creates contacts in batch.
+// The inputs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *ContactServiceOp) BatchCreate(contacts []interface{}) (*BatchResponse, error) {
+	return s.BatchCreateWithContext(context.Background(), contacts)
+}
+
+// BatchCreateWithContext creates contacts in batch with the given context.
+func (s *ContactServiceOp) BatchCreateWithContext(ctx context.Context, contacts []interface{}) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Contact.BatchCreate", contactBasePath)
+	return s.objects().BatchCreateWithContext(ctx, contacts)
+}
+
+// BatchUpdate updates contacts in batch.
+// The inputs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *ContactServiceOp) BatchUpdate(inputs []*BatchUpdateInput) (*BatchResponse, error) {
+	return s.BatchUpdateWithContext(context.Background(), inputs)
+}
+
+// BatchUpdateWithContext updates contacts in batch with the given context.
+func (s *ContactServiceOp) BatchUpdateWithContext(ctx context.Context, inputs []*BatchUpdateInput) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Contact.BatchUpdate", contactBasePath)
+	return s.objects().BatchUpdateWithContext(ctx, inputs)
+}
+
+// BatchUpsert updates contacts identified by a unique property, or creates them when they do not exist.
+// The inputs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *ContactServiceOp) BatchUpsert(inputs []*BatchUpsertInput) (*BatchResponse, error) {
+	return s.BatchUpsertWithContext(context.Background(), inputs)
+}
+
+// BatchUpsertWithContext upserts contacts in batch with the given context.
+func (s *ContactServiceOp) BatchUpsertWithContext(ctx context.Context, inputs []*BatchUpsertInput) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Contact.BatchUpsert", contactBasePath)
+	return s.objects().BatchUpsertWithContext(ctx, inputs)
+}
+
+// BatchArchive archives contacts in batch.
+// The IDs are sent in chunks of 100.
+func (s *ContactServiceOp) BatchArchive(contactIDs []string) error {
+	return s.BatchArchiveWithContext(context.Background(), contactIDs)
+}
+
+// BatchArchiveWithContext archives contacts in batch with the given context.
+func (s *ContactServiceOp) BatchArchiveWithContext(ctx context.Context, contactIDs []string) error {
+	ctx = withOperation(ctx, "CRM.Contact.BatchArchive", contactBasePath)
+	return s.objects().BatchArchiveWithContext(ctx, contactIDs)
+}
+
+func (s *ContactServiceOp) objects() *ObjectServiceOp {
+	return &ObjectServiceOp{objectType: contactBasePath, objectPath: s.contactPath, client: s.client}
+}
@@ -104,6 +104,98 @@ func TestContactServiceOp_Create(t *testing.T) {
 	}
 }
 
+func TestContactServiceOp_CreateOrGet(t *testing.T) {
+	conflict := []byte(`{"status":"error","message":"Contact already exists. Existing ID: 12345","category":"CONFLICT"}`)
+	existing := []byte(`{"id":"12345","properties":{"email":"hubspot@example.com","firstname":"Bryan"},"archived":false}`)
+
+	tests := []struct {
+		name        string
+		responses   []*hubspot.MockConfig
+		want        *hubspot.ResponseResource
+		wantCreated bool
+		wantPaths   []string
+		wantErr     error
+	}{
+		{
+			name: "Successfully create a contact",
+			responses: []*hubspot.MockConfig{
+				{Status: http.StatusCreated, Body: []byte(`{"id":"contact001","properties":{"email":"hubspot@example.com"},"archived":false}`)},
+			},
+			want: &hubspot.ResponseResource{
+				ID: "contact001",
+				Properties: &hubspot.Contact{
+					Email:     hubspot.NewString("hubspot@example.com"),
+					FirstName: hubspot.NewString("Ada"),
+					City:      hubspot.NewString("Tokyo"),
+				},
+			},
+			wantCreated: true,
+			wantPaths:   []string{"POST /crm/v3/objects/contacts"},
+		},
+		{
+			name: "Get the existing contact",
+			responses: []*hubspot.MockConfig{
+				{Status: http.StatusConflict, Body: conflict},
+				{Status: http.StatusOK, Body: existing},
+			},
+			want: &hubspot.ResponseResource{
+				ID: "12345",
+				Properties: &hubspot.Contact{
+					Email:     hubspot.NewString("hubspot@example.com"),
+					FirstName: hubspot.NewString("Bryan"),
+				},
+			},
+			wantCreated: false,
+			wantPaths:   []string{"POST /crm/v3/objects/contacts", "GET /crm/v3/objects/contacts/12345"},
+		},
+		{
+			name: "Received invalid request",
+			responses: []*hubspot.MockConfig{
+				{Status: http.StatusBadRequest, Body: []byte(`{"message":"Property values were not valid","category":"VALIDATION_ERROR"}`)},
+			},
+			wantPaths: []string{"POST /crm/v3/objects/contacts"},
+			wantErr: &hubspot.APIError{
+				HTTPStatusCode: http.StatusBadRequest,
+				Message:        "Property values were not valid",
+				Category:       hubspot.ValidationError,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(&http.Client{
+				Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+					conf := tt.responses[len(paths)]
+					paths = append(paths, req.Method+" "+req.URL.Path)
+					return hubspot.NewMockHTTPClient(conf).Transport.RoundTrip(req)
+				}),
+			}))
+
+			// The existing contact has no city, which must not be taken from the input.
+			input := &hubspot.Contact{
+				Email:     hubspot.NewString("hubspot@example.com"),
+				FirstName: hubspot.NewString("Ada"),
+				City:      hubspot.NewString("Tokyo"),
+			}
+			got, created, err := c.CRM.Contact.CreateOrGet(input, nil)
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("CreateOrGet() error mismatch: want %s got %s", tt.wantErr, err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmpTimeOption); diff != "" {
+				t.Errorf("CreateOrGet() response mismatch (-want +got):%s", diff)
+			}
+			if tt.wantCreated != created {
+				t.Errorf("CreateOrGet() created mismatch: want %t got %t", tt.wantCreated, created)
+			}
+			if diff := cmp.Diff(tt.wantPaths, paths); diff != "" {
+				t.Errorf("request paths mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
 func TestContactServiceOp_Update(t *testing.T) {
 	contact := &hubspot.Contact{
 		Email:       hubspot.NewString("hubspot@example.com"),
@@ -1,5 +1,7 @@
 package hubspot
 
+import "context"
+
 type IdentificationTokenResponse struct {
 	Token string `json:"token"`
 }
@@ -12,6 +14,7 @@ type IdentificationTokenRequest struct {
 
 type VisitorIdentificationService interface {
 	GenerateIdentificationToken(option IdentificationTokenRequest) (*IdentificationTokenResponse, error)
+	GenerateIdentificationTokenWithContext(ctx context.Context, option IdentificationTokenRequest) (*IdentificationTokenResponse, error)
 }
 
 type VisitorIdentificationServiceOp struct {
@@ -22,9 +25,14 @@ type VisitorIdentificationServiceOp struct {
 var _ VisitorIdentificationService = (*VisitorIdentificationServiceOp)(nil)
 
 func (s *VisitorIdentificationServiceOp) GenerateIdentificationToken(option IdentificationTokenRequest) (*IdentificationTokenResponse, error) {
+	return s.GenerateIdentificationTokenWithContext(context.Background(), option)
+}
+
+func (s *VisitorIdentificationServiceOp) GenerateIdentificationTokenWithContext(ctx context.Context, option IdentificationTokenRequest) (*IdentificationTokenResponse, error) {
+	ctx = withOperation(ctx, "Conversation.VisitorIdentification.GenerateIdentificationToken", "")
 	response := &IdentificationTokenResponse{}
 	path := s.basePath + "/tokens/create"
-	if err := s.client.Post(path, option, response); err != nil {
+	if err := s.client.PostWithContext(ctx, path, option, response); err != nil {
 		return nil, err
 	}
 	return response, nil
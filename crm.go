@@ -17,6 +17,9 @@ type CRM struct {
 	Schemas    CrmSchemasService
 	Properties CrmPropertiesService
 	Tickets    CrmTicketsService
+
+	crmPath string
+	client  *Client
 }
 
 func newCRM(c *Client) *CRM {
@@ -54,5 +57,7 @@ func newCRM(c *Client) *CRM {
 			crmTicketsPath: fmt.Sprintf("%s/%s/%s", crmPath, objectsBasePath, crmTicketsBasePath),
 			client:         c,
 		},
+		crmPath: crmPath,
+		client:  c,
 	}
 }
@@ -1,6 +1,7 @@
 package hubspot
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -12,10 +13,15 @@ const (
 // Reference: https://developers.hubspot.com/docs/api/crm/imports
 type CrmImportsService interface {
 	Active(option *CrmActiveImportOptions) (interface{}, error)
+	ActiveWithContext(ctx context.Context, option *CrmActiveImportOptions) (interface{}, error)
 	Get(int64) (interface{}, error)
+	GetWithContext(ctx context.Context, importId int64) (interface{}, error)
 	Cancel(int64) (interface{}, error)
+	CancelWithContext(ctx context.Context, importId int64) (interface{}, error)
 	Errors(int64, *CrmImportErrorsOptions) (interface{}, error)
+	ErrorsWithContext(ctx context.Context, importId int64, option *CrmImportErrorsOptions) (interface{}, error)
 	Start(*CrmImportConfig) (interface{}, error)
+	StartWithContext(ctx context.Context, importRequest *CrmImportConfig) (interface{}, error)
 }
 
 // CrmImportsServiceOp handles communication with the bulk CRM import endpoints of the HubSpot API.
@@ -32,9 +38,14 @@ type CrmImportErrorsOptions struct {
 }
 
 func (s *CrmImportsServiceOp) Errors(importId int64, option *CrmImportErrorsOptions) (interface{}, error) {
+	return s.ErrorsWithContext(context.Background(), importId, option)
+}
+
+func (s *CrmImportsServiceOp) ErrorsWithContext(ctx context.Context, importId int64, option *CrmImportErrorsOptions) (interface{}, error) {
+	ctx = withOperation(ctx, "CRM.Imports.Errors", "")
 	resource := make(map[string]interface{})
 	path := fmt.Sprintf("%s/%d/errors", s.crmImportsPath, importId)
-	if err := s.client.Get(path, &resource, option); err != nil {
+	if err := s.client.GetWithContext(ctx, path, &resource, option); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -47,26 +58,41 @@ type CrmActiveImportOptions struct {
 }
 
 func (s *CrmImportsServiceOp) Active(option *CrmActiveImportOptions) (interface{}, error) {
+	return s.ActiveWithContext(context.Background(), option)
+}
+
+func (s *CrmImportsServiceOp) ActiveWithContext(ctx context.Context, option *CrmActiveImportOptions) (interface{}, error) {
+	ctx = withOperation(ctx, "CRM.Imports.Active", "")
 	resource := make(map[string]interface{})
-	if err := s.client.Get(s.crmImportsPath, &resource, option); err != nil {
+	if err := s.client.GetWithContext(ctx, s.crmImportsPath, &resource, option); err != nil {
 		return nil, err
 	}
 	return resource, nil
 }
 
 func (s *CrmImportsServiceOp) Get(importId int64) (interface{}, error) {
+	return s.GetWithContext(context.Background(), importId)
+}
+
+func (s *CrmImportsServiceOp) GetWithContext(ctx context.Context, importId int64) (interface{}, error) {
+	ctx = withOperation(ctx, "CRM.Imports.Get", "")
 	resource := make(map[string]interface{})
 	path := fmt.Sprintf("%s/%d", s.crmImportsPath, importId)
-	if err := s.client.Get(path, &resource, nil); err != nil {
+	if err := s.client.GetWithContext(ctx, path, &resource, nil); err != nil {
 		return nil, err
 	}
 	return resource, nil
 }
 
 func (s *CrmImportsServiceOp) Cancel(importId int64) (interface{}, error) {
+	return s.CancelWithContext(context.Background(), importId)
+}
+
+func (s *CrmImportsServiceOp) CancelWithContext(ctx context.Context, importId int64) (interface{}, error) {
+	ctx = withOperation(ctx, "CRM.Imports.Cancel", "")
 	resource := make(map[string]interface{})
 	path := fmt.Sprintf("%s/%d/cancel", s.crmImportsPath, importId)
-	if err := s.client.Post(path, &resource, nil); err != nil {
+	if err := s.client.PostWithContext(ctx, path, &resource, nil); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -2,6 +2,7 @@ package hubspot
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -74,6 +75,11 @@ func addFilesToMultipart(writer *multipart.Writer, importRequest *CrmImportConfi
 }
 
 func (s *CrmImportsServiceOp) Start(importRequest *CrmImportConfig) (interface{}, error) {
+	return s.StartWithContext(context.Background(), importRequest)
+}
+
+func (s *CrmImportsServiceOp) StartWithContext(ctx context.Context, importRequest *CrmImportConfig) (interface{}, error) {
+	ctx = withOperation(ctx, "CRM.Imports.Start", "")
 	resource := make(map[string]interface{})
 
 	// Body is our final result that we pass to postMultipart
@@ -96,7 +102,7 @@ func (s *CrmImportsServiceOp) Start(importRequest *CrmImportConfig) (interface{}
 		return nil, err
 	}
 
-	if err := s.client.PostMultipart(s.crmImportsPath, writer.Boundary(), body.Bytes(), &resource); err != nil {
+	if err := s.client.PostMultipartWithContext(ctx, s.crmImportsPath, writer.Boundary(), body.Bytes(), &resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -1,6 +1,7 @@
 package hubspot
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -58,10 +59,15 @@ type CrmPropertyOptions struct {
 // Reference: https://developers.hubspot.com/docs/api/crm/properties
 type CrmPropertiesService interface {
 	List(objectType string) (*CrmPropertiesList, error)
+	ListWithContext(ctx context.Context, objectType string) (*CrmPropertiesList, error)
 	Create(objectType string, reqData interface{}) (*CrmProperty, error)
+	CreateWithContext(ctx context.Context, objectType string, reqData interface{}) (*CrmProperty, error)
 	Get(objectType string, propertyName string) (*CrmProperty, error)
+	GetWithContext(ctx context.Context, objectType string, propertyName string) (*CrmProperty, error)
 	Delete(objectType string, propertyName string) error
+	DeleteWithContext(ctx context.Context, objectType string, propertyName string) error
 	Update(objectType string, propertyName string, reqData interface{}) (*CrmProperty, error)
+	UpdateWithContext(ctx context.Context, objectType string, propertyName string, reqData interface{}) (*CrmProperty, error)
 }
 
 // CrmPropertiesServiceOp handles communication with the CRM properties endpoint.
@@ -73,41 +79,66 @@ type CrmPropertiesServiceOp struct {
 var _ CrmPropertiesService = (*CrmPropertiesServiceOp)(nil)
 
 func (s *CrmPropertiesServiceOp) List(objectType string) (*CrmPropertiesList, error) {
+	return s.ListWithContext(context.Background(), objectType)
+}
+
+func (s *CrmPropertiesServiceOp) ListWithContext(ctx context.Context, objectType string) (*CrmPropertiesList, error) {
+	ctx = withOperation(ctx, "CRM.Properties.List", objectType)
 	var resource CrmPropertiesList
 	path := fmt.Sprintf("%s/%s", s.crmPropertiesPath, objectType)
-	if err := s.client.Get(path, &resource, nil); err != nil {
+	if err := s.client.GetWithContext(ctx, path, &resource, nil); err != nil {
 		return nil, err
 	}
 	return &resource, nil
 }
 
 func (s *CrmPropertiesServiceOp) Get(objectType, propertyName string) (*CrmProperty, error) {
+	return s.GetWithContext(context.Background(), objectType, propertyName)
+}
+
+func (s *CrmPropertiesServiceOp) GetWithContext(ctx context.Context, objectType, propertyName string) (*CrmProperty, error) {
+	ctx = withOperation(ctx, "CRM.Properties.Get", objectType)
 	var resource CrmProperty
 	path := fmt.Sprintf("%s/%s/%s", s.crmPropertiesPath, objectType, propertyName)
-	if err := s.client.Get(path, &resource, nil); err != nil {
+	if err := s.client.GetWithContext(ctx, path, &resource, nil); err != nil {
 		return nil, err
 	}
 	return &resource, nil
 }
 
 func (s *CrmPropertiesServiceOp) Create(objectType string, reqData interface{}) (*CrmProperty, error) {
+	return s.CreateWithContext(context.Background(), objectType, reqData)
+}
+
+func (s *CrmPropertiesServiceOp) CreateWithContext(ctx context.Context, objectType string, reqData interface{}) (*CrmProperty, error) {
+	ctx = withOperation(ctx, "CRM.Properties.Create", objectType)
 	var resource CrmProperty
 	path := fmt.Sprintf("%s/%s", s.crmPropertiesPath, objectType)
-	if err := s.client.Post(path, reqData, &resource); err != nil {
+	if err := s.client.PostWithContext(ctx, path, reqData, &resource); err != nil {
 		return nil, err
 	}
 	return &resource, nil
 }
 
 func (s *CrmPropertiesServiceOp) Delete(objectType string, propertyName string) error {
+	return s.DeleteWithContext(context.Background(), objectType, propertyName)
+}
+
+func (s *CrmPropertiesServiceOp) DeleteWithContext(ctx context.Context, objectType string, propertyName string) error {
+	ctx = withOperation(ctx, "CRM.Properties.Delete", objectType)
 	path := fmt.Sprintf("%s/%s/%s", s.crmPropertiesPath, objectType, propertyName)
-	return s.client.Delete(path, nil)
+	return s.client.DeleteWithContext(ctx, path, nil)
 }
 
 func (s *CrmPropertiesServiceOp) Update(objectType string, propertyName string, reqData interface{}) (*CrmProperty, error) {
+	return s.UpdateWithContext(context.Background(), objectType, propertyName, reqData)
+}
+
+func (s *CrmPropertiesServiceOp) UpdateWithContext(ctx context.Context, objectType string, propertyName string, reqData interface{}) (*CrmProperty, error) {
+	ctx = withOperation(ctx, "CRM.Properties.Update", objectType)
 	var resource CrmProperty
 	path := fmt.Sprintf("%s/%s/%s", s.crmPropertiesPath, objectType, propertyName)
-	if err := s.client.Patch(path, reqData, &resource); err != nil {
+	if err := s.client.PatchWithContext(ctx, path, reqData, &resource); err != nil {
 		return nil, err
 	}
 	return &resource, nil
@@ -1,6 +1,7 @@
 package hubspot
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -52,10 +53,15 @@ type CrmSchema struct {
 // Reference: https://developers.hubspot.com/docs/api/crm/crm-custom-objects
 type CrmSchemasService interface {
 	List() (*CrmSchemasList, error)
+	ListWithContext(ctx context.Context) (*CrmSchemasList, error)
 	Create(reqData interface{}) (*CrmSchema, error)
+	CreateWithContext(ctx context.Context, reqData interface{}) (*CrmSchema, error)
 	Get(objectType string) (*CrmSchema, error)
+	GetWithContext(ctx context.Context, objectType string) (*CrmSchema, error)
 	Delete(objectType string, option *RequestQueryOption) error
+	DeleteWithContext(ctx context.Context, objectType string, option *RequestQueryOption) error
 	Update(objectType string, reqData interface{}) (*CrmSchema, error)
+	UpdateWithContext(ctx context.Context, objectType string, reqData interface{}) (*CrmSchema, error)
 }
 
 // CrmSchemasServiceOp handles communication with the CRM schemas endpoint.
@@ -67,39 +73,64 @@ type CrmSchemasServiceOp struct {
 var _ CrmSchemasService = (*CrmSchemasServiceOp)(nil)
 
 func (s *CrmSchemasServiceOp) List() (*CrmSchemasList, error) {
+	return s.ListWithContext(context.Background())
+}
+
+func (s *CrmSchemasServiceOp) ListWithContext(ctx context.Context) (*CrmSchemasList, error) {
+	ctx = withOperation(ctx, "CRM.Schemas.List", "")
 	var resource CrmSchemasList
-	if err := s.client.Get(s.crmSchemasPath, &resource, nil); err != nil {
+	if err := s.client.GetWithContext(ctx, s.crmSchemasPath, &resource, nil); err != nil {
 		return nil, err
 	}
 	return &resource, nil
 }
 
 func (s *CrmSchemasServiceOp) Create(reqData interface{}) (*CrmSchema, error) {
+	return s.CreateWithContext(context.Background(), reqData)
+}
+
+func (s *CrmSchemasServiceOp) CreateWithContext(ctx context.Context, reqData interface{}) (*CrmSchema, error) {
+	ctx = withOperation(ctx, "CRM.Schemas.Create", "")
 	var resource CrmSchema
-	if err := s.client.Post(s.crmSchemasPath, reqData, &resource); err != nil {
+	if err := s.client.PostWithContext(ctx, s.crmSchemasPath, reqData, &resource); err != nil {
 		return nil, err
 	}
 	return &resource, nil
 }
 
 func (s *CrmSchemasServiceOp) Get(objectType string) (*CrmSchema, error) {
+	return s.GetWithContext(context.Background(), objectType)
+}
+
+func (s *CrmSchemasServiceOp) GetWithContext(ctx context.Context, objectType string) (*CrmSchema, error) {
+	ctx = withOperation(ctx, "CRM.Schemas.Get", objectType)
 	var resource CrmSchema
 	path := fmt.Sprintf("%s/%s", s.crmSchemasPath, objectType)
-	if err := s.client.Get(path, &resource, nil); err != nil {
+	if err := s.client.GetWithContext(ctx, path, &resource, nil); err != nil {
 		return nil, err
 	}
 	return &resource, nil
 }
 
 func (s *CrmSchemasServiceOp) Delete(objectType string, option *RequestQueryOption) error {
+	return s.DeleteWithContext(context.Background(), objectType, option)
+}
+
+func (s *CrmSchemasServiceOp) DeleteWithContext(ctx context.Context, objectType string, option *RequestQueryOption) error {
+	ctx = withOperation(ctx, "CRM.Schemas.Delete", objectType)
 	path := fmt.Sprintf("%s/%s", s.crmSchemasPath, objectType)
-	return s.client.Delete(path, option)
+	return s.client.DeleteWithContext(ctx, path, option)
 }
 
 func (s *CrmSchemasServiceOp) Update(objectType string, reqData interface{}) (*CrmSchema, error) {
+	return s.UpdateWithContext(context.Background(), objectType, reqData)
+}
+
+func (s *CrmSchemasServiceOp) UpdateWithContext(ctx context.Context, objectType string, reqData interface{}) (*CrmSchema, error) {
+	ctx = withOperation(ctx, "CRM.Schemas.Update", objectType)
 	var resource CrmSchema
 	path := fmt.Sprintf("%s/%s", s.crmSchemasPath, objectType)
-	if err := s.client.Patch(path, reqData, &resource); err != nil {
+	if err := s.client.PatchWithContext(ctx, path, reqData, &resource); err != nil {
 		return nil, err
 	}
 	return &resource, nil
@@ -1,6 +1,9 @@
 package hubspot
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 const (
 	crmTicketsBasePath = "tickets"
@@ -10,11 +13,17 @@ const (
 // Reference: https://developers.hubspot.com/docs/api/crm/tickets
 type CrmTicketsService interface {
 	List(option *RequestQueryOption) (*CrmTicketsList, error)
+	ListWithContext(ctx context.Context, option *RequestQueryOption) (*CrmTicketsList, error)
 	Get(ticketId string, option *RequestQueryOption) (*CrmTicket, error)
+	GetWithContext(ctx context.Context, ticketId string, option *RequestQueryOption) (*CrmTicket, error)
 	Create(reqData *CrmTicketCreateRequest) (*CrmTicket, error)
+	CreateWithContext(ctx context.Context, reqData *CrmTicketCreateRequest) (*CrmTicket, error)
 	Archive(ticketId string) error
+	ArchiveWithContext(ctx context.Context, ticketId string) error
 	Update(ticketId string, reqData *CrmTicketUpdateRequest) (*CrmTicket, error)
+	UpdateWithContext(ctx context.Context, ticketId string, reqData *CrmTicketUpdateRequest) (*CrmTicket, error)
 	Search(reqData *CrmTicketSearchRequest) (*CrmTicketsList, error)
+	SearchWithContext(ctx context.Context, reqData *CrmTicketSearchRequest) (*CrmTicketsList, error)
 }
 
 // CrmTicketsServiceOp handles communication with the CRM tickets endpoints of the HubSpot API.
@@ -51,17 +60,27 @@ type CrmTicketsList struct {
 }
 
 func (s *CrmTicketsServiceOp) List(option *RequestQueryOption) (*CrmTicketsList, error) {
+	return s.ListWithContext(context.Background(), option)
+}
+
+func (s *CrmTicketsServiceOp) ListWithContext(ctx context.Context, option *RequestQueryOption) (*CrmTicketsList, error) {
+	ctx = withOperation(ctx, "CRM.Tickets.List", crmTicketsBasePath)
 	var resource CrmTicketsList
-	if err := s.client.Get(s.crmTicketsPath, &resource, option); err != nil {
+	if err := s.client.GetWithContext(ctx, s.crmTicketsPath, &resource, option); err != nil {
 		return nil, err
 	}
 	return &resource, nil
 }
 
 func (s *CrmTicketsServiceOp) Get(ticketId string, option *RequestQueryOption) (*CrmTicket, error) {
+	return s.GetWithContext(context.Background(), ticketId, option)
+}
+
+func (s *CrmTicketsServiceOp) GetWithContext(ctx context.Context, ticketId string, option *RequestQueryOption) (*CrmTicket, error) {
+	ctx = withOperation(ctx, "CRM.Tickets.Get", crmTicketsBasePath)
 	var resource CrmTicket
 	path := fmt.Sprintf("%s/%s", s.crmTicketsPath, ticketId)
-	if err := s.client.Get(path, &resource, option); err != nil {
+	if err := s.client.GetWithContext(ctx, path, &resource, option); err != nil {
 		return nil, err
 	}
 	return &resource, nil
@@ -89,22 +108,37 @@ type CrmTicketCreateRequest struct {
 type CrmTicketUpdateRequest = CrmTicketCreateRequest
 
 func (s *CrmTicketsServiceOp) Create(reqData *CrmTicketCreateRequest) (*CrmTicket, error) {
+	return s.CreateWithContext(context.Background(), reqData)
+}
+
+func (s *CrmTicketsServiceOp) CreateWithContext(ctx context.Context, reqData *CrmTicketCreateRequest) (*CrmTicket, error) {
+	ctx = withOperation(ctx, "CRM.Tickets.Create", crmTicketsBasePath)
 	var resource CrmTicket
-	if err := s.client.Post(s.crmTicketsPath, reqData, &resource); err != nil {
+	if err := s.client.PostWithContext(ctx, s.crmTicketsPath, reqData, &resource); err != nil {
 		return nil, err
 	}
 	return &resource, nil
 }
 
 func (s *CrmTicketsServiceOp) Archive(ticketId string) error {
+	return s.ArchiveWithContext(context.Background(), ticketId)
+}
+
+func (s *CrmTicketsServiceOp) ArchiveWithContext(ctx context.Context, ticketId string) error {
+	ctx = withOperation(ctx, "CRM.Tickets.Archive", crmTicketsBasePath)
 	path := fmt.Sprintf("%s/%s", s.crmTicketsPath, ticketId)
-	return s.client.Delete(path, nil)
+	return s.client.DeleteWithContext(ctx, path, nil)
 }
 
 func (s *CrmTicketsServiceOp) Update(ticketId string, reqData *CrmTicketUpdateRequest) (*CrmTicket, error) {
+	return s.UpdateWithContext(context.Background(), ticketId, reqData)
+}
+
+func (s *CrmTicketsServiceOp) UpdateWithContext(ctx context.Context, ticketId string, reqData *CrmTicketUpdateRequest) (*CrmTicket, error) {
+	ctx = withOperation(ctx, "CRM.Tickets.Update", crmTicketsBasePath)
 	var resource CrmTicket
 	path := fmt.Sprintf("%s/%s", s.crmTicketsPath, ticketId)
-	if err := s.client.Patch(path, reqData, &resource); err != nil {
+	if err := s.client.PatchWithContext(ctx, path, reqData, &resource); err != nil {
 		return nil, err
 	}
 	return &resource, nil
@@ -132,9 +166,14 @@ type CrmTicketSearchRequest struct {
 }
 
 func (s *CrmTicketsServiceOp) Search(reqData *CrmTicketSearchRequest) (*CrmTicketsList, error) {
+	return s.SearchWithContext(context.Background(), reqData)
+}
+
+func (s *CrmTicketsServiceOp) SearchWithContext(ctx context.Context, reqData *CrmTicketSearchRequest) (*CrmTicketsList, error) {
+	ctx = withOperation(ctx, "CRM.Tickets.Search", crmTicketsBasePath)
 	var resource CrmTicketsList
 	path := fmt.Sprintf("%s/search", s.crmTicketsPath)
-	if err := s.client.Post(path, reqData, &resource); err != nil {
+	if err := s.client.PostWithContext(ctx, path, reqData, &resource); err != nil {
 		return nil, err
 	}
 	return &resource, nil
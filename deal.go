@@ -1,5 +1,7 @@
 package hubspot
 
+import "context"
+
 const (
 	dealBasePath = "deals"
 )
@@ -10,11 +12,28 @@ const (
 // Reference: https://developers.hubspot.com/docs/api/crm/deals
 type DealService interface {
 	Get(dealID string, deal interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	GetWithContext(ctx context.Context, dealID string, deal interface{}, option *RequestQueryOption) (*ResponseResource, error)
 	Create(deal interface{}) (*ResponseResource, error)
+	CreateWithContext(ctx context.Context, deal interface{}) (*ResponseResource, error)
 	Update(dealID string, deal interface{}) (*ResponseResource, error)
+	UpdateWithContext(ctx context.Context, dealID string, deal interface{}) (*ResponseResource, error)
 	AssociateAnotherObj(dealID string, conf *AssociationConfig) (*ResponseResource, error)
+	AssociateAnotherObjWithContext(ctx context.Context, dealID string, conf *AssociationConfig) (*ResponseResource, error)
 	SearchByName(dealName string) (*DealSearchResponse, error)
+	SearchByNameWithContext(ctx context.Context, dealName string) (*DealSearchResponse, error)
 	Search(req *DealSearchRequest) (*DealSearchResponse, error)
+	SearchWithContext(ctx context.Context, req *DealSearchRequest) (*DealSearchResponse, error)
+	SearchAll(req *DealSearchRequest, option *SearchPartitionOption) *Pager
+	BatchRead(dealIDs []string, option *BatchReadOption) (*BatchResponse, error)
+	BatchReadWithContext(ctx context.Context, dealIDs []string, option *BatchReadOption) (*BatchResponse, error)
+	BatchCreate(deals []interface{}) (*BatchResponse, error)
+	BatchCreateWithContext(ctx context.Context, deals []interface{}) (*BatchResponse, error)
+	BatchUpdate(inputs []*BatchUpdateInput) (*BatchResponse, error)
+	BatchUpdateWithContext(ctx context.Context, inputs []*BatchUpdateInput) (*BatchResponse, error)
+	BatchUpsert(inputs []*BatchUpsertInput) (*BatchResponse, error)
+	BatchUpsertWithContext(ctx context.Context, inputs []*BatchUpsertInput) (*BatchResponse, error)
+	BatchArchive(dealIDs []string) error
+	BatchArchiveWithContext(ctx context.Context, dealIDs []string) error
 }
 
 // DealServiceOp handles communication with the product related methods of the HubSpot API.
@@ -32,6 +51,7 @@ type DealSearchRequest struct {
 type DealSearchResponse struct {
 	Total   int            `json:"total,omitempty"`
 	Results []DealResponse `json:"results,omitempty"`
+	Paging  *Paging        `json:"paging,omitempty"`
 }
 
 type DealResponse struct {
@@ -118,8 +138,14 @@ var defaultDealFields = []string{
 // If you specify a non-existent field, it will be ignored.
 // e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
 func (s *DealServiceOp) Get(dealID string, deal interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	return s.GetWithContext(context.Background(), dealID, deal, option)
+}
+
+// GetWithContext gets a deal with the given context.
+func (s *DealServiceOp) GetWithContext(ctx context.Context, dealID string, deal interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Deal.Get", dealBasePath)
 	resource := &ResponseResource{Properties: deal}
-	if err := s.client.Get(s.dealPath+"/"+dealID, resource, option.setupProperties(defaultDealFields)); err != nil {
+	if err := s.client.GetWithContext(ctx, s.dealPath+"/"+dealID, resource, option.setupProperties(defaultDealFields)); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -129,9 +155,15 @@ func (s *DealServiceOp) Get(dealID string, deal interface{}, option *RequestQuer
 // In order to bind the created content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Deal in your own structure.
 func (s *DealServiceOp) Create(deal interface{}) (*ResponseResource, error) {
+	return s.CreateWithContext(context.Background(), deal)
+}
+
+// CreateWithContext creates a new deal with the given context.
+func (s *DealServiceOp) CreateWithContext(ctx context.Context, deal interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Deal.Create", dealBasePath)
 	req := &RequestPayload{Properties: deal}
 	resource := &ResponseResource{Properties: deal}
-	if err := s.client.Post(s.dealPath, req, resource); err != nil {
+	if err := s.client.PostWithContext(ctx, s.dealPath, req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -141,9 +173,15 @@ func (s *DealServiceOp) Create(deal interface{}) (*ResponseResource, error) {
 // In order to bind the updated content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Deal in your own structure.
 func (s *DealServiceOp) Update(dealID string, deal interface{}) (*ResponseResource, error) {
+	return s.UpdateWithContext(context.Background(), dealID, deal)
+}
+
+// UpdateWithContext updates a deal with the given context.
+func (s *DealServiceOp) UpdateWithContext(ctx context.Context, dealID string, deal interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Deal.Update", dealBasePath)
 	req := &RequestPayload{Properties: deal}
 	resource := &ResponseResource{Properties: deal}
-	if err := s.client.Patch(s.dealPath+"/"+dealID, req, resource); err != nil {
+	if err := s.client.PatchWithContext(ctx, s.dealPath+"/"+dealID, req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -152,8 +190,14 @@ func (s *DealServiceOp) Update(dealID string, deal interface{}) (*ResponseResour
 // AssociateAnotherObj associates Deal with another HubSpot objects.
 // If you want to associate a custom object, please use a defined value in HubSpot.
 func (s *DealServiceOp) AssociateAnotherObj(dealID string, conf *AssociationConfig) (*ResponseResource, error) {
+	return s.AssociateAnotherObjWithContext(context.Background(), dealID, conf)
+}
+
+// AssociateAnotherObjWithContext associates Deal with another HubSpot objects using the given context.
+func (s *DealServiceOp) AssociateAnotherObjWithContext(ctx context.Context, dealID string, conf *AssociationConfig) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Deal.AssociateAnotherObj", dealBasePath)
 	resource := &ResponseResource{Properties: &Deal{}}
-	if err := s.client.Put(s.dealPath+"/"+dealID+"/"+conf.makeAssociationPath(), nil, resource); err != nil {
+	if err := s.client.PutWithContext(ctx, s.dealPath+"/"+dealID+"/"+conf.makeAssociationPath(), nil, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -162,6 +206,12 @@ func (s *DealServiceOp) AssociateAnotherObj(dealID string, conf *AssociationConf
 // SearchByName searches for deals by deal name.
 // EXPERIMENTAL: This method is experimental and the interface may change in the future to support custom properties.
 func (s *DealServiceOp) SearchByName(dealName string) (*DealSearchResponse, error) {
+	return s.SearchByNameWithContext(context.Background(), dealName)
+}
+
+// SearchByNameWithContext searches for deals by deal name with the given context.
+func (s *DealServiceOp) SearchByNameWithContext(ctx context.Context, dealName string) (*DealSearchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Deal.SearchByName", dealBasePath)
 	req := &DealSearchRequest{
 		SearchOptions: SearchOptions{
 			FilterGroups: []FilterGroup{
@@ -177,15 +227,101 @@ func (s *DealServiceOp) SearchByName(dealName string) (*DealSearchResponse, erro
 			},
 		},
 	}
-	return s.Search(req)
+	return s.SearchWithContext(ctx, req)
 }
 
 // Search searches for deals based on the provided search request.
 // EXPERIMENTAL: This method is experimental and the interface may change in the future to support custom properties.
 func (s *DealServiceOp) Search(req *DealSearchRequest) (*DealSearchResponse, error) {
+	return s.SearchWithContext(context.Background(), req)
+}
+
+// SearchWithContext searches for deals based on the provided search request with the given context.
+func (s *DealServiceOp) SearchWithContext(ctx context.Context, req *DealSearchRequest) (*DealSearchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Deal.Search", dealBasePath)
 	resource := &DealSearchResponse{}
-	if err := s.client.Post(dealBasePath+"/search", req, resource); err != nil {
+	if err := s.client.PostWithContext(ctx, s.dealPath+"/search", req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
 }
+
+// SearchAll returns a pager searching for deals beyond the 10,000 results HubSpot returns for a search,
+// by partitioning the search by option.Property. See NewPartitionedSearchPager.
+func (s *DealServiceOp) SearchAll(req *DealSearchRequest, option *SearchPartitionOption) *Pager {
+	var opts SearchOptions
+	if req != nil {
+		opts = req.SearchOptions
+	}
+	objects := s.objects()
+	p := newPartitionedSearchPager(func(ctx context.Context, opts *SearchOptions) (*ObjectList, error) {
+		ctx = withOperation(ctx, "CRM.Deal.Search", dealBasePath)
+		return objects.SearchWithContext(ctx, &ObjectSearchRequest{SearchOptions: *opts})
+	}, opts, option)
+	p.trace = s.client.operationTracer("CRM.Deal.Search", dealBasePath)
+	return p
+}
+
+// BatchRead reads deals by their IDs, or by the values of option.IDProperty.
+// The IDs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *DealServiceOp) BatchRead(dealIDs []string, option *BatchReadOption) (*BatchResponse, error) {
+	return s.BatchReadWithContext(context.Background(), dealIDs, option)
+}
+
+// BatchReadWithContext reads deals in batch with the given context.
+func (s *DealServiceOp) BatchReadWithContext(ctx context.Context, dealIDs []string, option *BatchReadOption) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Deal.BatchRead", dealBasePath)
+	return s.objects().BatchReadWithContext(ctx, dealIDs, option)
+}
+
+// BatchCreate creates deals in batch.
+// The inputs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *DealServiceOp) BatchCreate(deals []interface{}) (*BatchResponse, error) {
+	return s.BatchCreateWithContext(context.Background(), deals)
+}
+
+// BatchCreateWithContext creates deals in batch with the given context.
+func (s *DealServiceOp) BatchCreateWithContext(ctx context.Context, deals []interface{}) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Deal.BatchCreate", dealBasePath)
+	return s.objects().BatchCreateWithContext(ctx, deals)
+}
+
+// BatchUpdate updates deals in batch.
+// The inputs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *DealServiceOp) BatchUpdate(inputs []*BatchUpdateInput) (*BatchResponse, error) {
+	return s.BatchUpdateWithContext(context.Background(), inputs)
+}
+
+// BatchUpdateWithContext updates deals in batch with the given context.
+func (s *DealServiceOp) BatchUpdateWithContext(ctx context.Context, inputs []*BatchUpdateInput) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Deal.BatchUpdate", dealBasePath)
+	return s.objects().BatchUpdateWithContext(ctx, inputs)
+}
+
+// BatchUpsert updates deals identified by a unique property, or creates them when they do not exist.
+// The inputs are sent in chunks of 100. When some of them fail, a *BatchError is returned along with the other results.
+func (s *DealServiceOp) BatchUpsert(inputs []*BatchUpsertInput) (*BatchResponse, error) {
+	return s.BatchUpsertWithContext(context.Background(), inputs)
+}
+
+// BatchUpsertWithContext upserts deals in batch with the given context.
+func (s *DealServiceOp) BatchUpsertWithContext(ctx context.Context, inputs []*BatchUpsertInput) (*BatchResponse, error) {
+	ctx = withOperation(ctx, "CRM.Deal.BatchUpsert", dealBasePath)
+	return s.objects().BatchUpsertWithContext(ctx, inputs)
+}
+
+// BatchArchive archives deals in batch.
+// The IDs are sent in chunks of 100.
+func (s *DealServiceOp) BatchArchive(dealIDs []string) error {
+	return s.BatchArchiveWithContext(context.Background(), dealIDs)
+}
+
+// BatchArchiveWithContext archives deals in batch with the given context.
+func (s *DealServiceOp) BatchArchiveWithContext(ctx context.Context, dealIDs []string) error {
+	ctx = withOperation(ctx, "CRM.Deal.BatchArchive", dealBasePath)
+	return s.objects().BatchArchiveWithContext(ctx, dealIDs)
+}
+
+func (s *DealServiceOp) objects() *ObjectServiceOp {
+	return &ObjectServiceOp{objectType: dealBasePath, objectPath: s.dealPath, client: s.client}
+}
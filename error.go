@@ -1,12 +1,27 @@
 package hubspot
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"time"
+)
 
 const (
 	// ValidationError is the APIError.Category.
 	// This is returned by HubSpot when the HTTP Status is 400.
 	// In this case, the verification details error will be included in Details
 	ValidationError = "VALIDATION_ERROR"
+	// MissingScopesError is the APIError.Category returned with the HTTP Status 403
+	// when the token has not been granted the scopes required by the endpoint.
+	MissingScopesError = "MISSING_SCOPES"
+	// ObjectNotFoundError is the APIError.Category returned when the requested object does not exist.
+	ObjectNotFoundError = "OBJECT_NOT_FOUND"
+	// RateLimitsError is the APIError.Category returned with the HTTP Status 429.
+	RateLimitsError = "RATE_LIMITS"
 
 	// InvalidEmailError is the value of ErrDetail.Error when an error occurs in the Email validation.
 	InvalidEmailError = "INVALID_EMAIL"
@@ -14,6 +29,18 @@ const (
 	UnknownDetailError = "UNKNOWN_DETAIL"
 )
 
+// Errors used to classify an APIError with errors.Is.
+// e.g. errors.Is(err, hubspot.ErrNotFound)
+var (
+	ErrNotFound      = errors.New("resource not found")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
+	ErrMissingScopes = errors.New("missing scopes")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrConflict      = errors.New("conflict")
+	ErrValidation    = errors.New("validation error")
+)
+
 type APIError struct {
 	HTTPStatusCode int         `json:"-"`
 	Status         string      `json:"status,omitempty"`
@@ -24,6 +51,59 @@ type APIError struct {
 	SubCategory    string      `json:"subCategory,omitempty"`
 	Links          ErrLinks    `json:"links,omitempty"`
 	Details        []ErrDetail `json:"details,omitempty"`
+
+	// RetryAfter is the wait requested by HubSpot with the Retry-After header, mostly along with ErrRateLimited.
+	RetryAfter time.Duration `json:"-"`
+	// RawBody is the response body when it could not be read as a HubSpot error,
+	// e.g. an HTML page returned by a proxy.
+	RawBody string `json:"-"`
+	// Operation is the operation denied because of missing scopes, e.g. CRM.Contact.Create, when known.
+	Operation string `json:"-"`
+}
+
+// ConflictError tells the details of an APIError with the HTTP Status 409,
+// e.g. when a contact is created with an email address which is already used.
+// It is retrieved from the APIError with errors.As, and the APIError can be retrieved from it with errors.As too.
+type ConflictError struct {
+	*APIError
+	// ExistingID is the ID of the object which already exists, when HubSpot reports it.
+	ExistingID string
+	// Property is the name of the property whose value conflicts, when HubSpot reports it.
+	Property string
+}
+
+func newConflictError(apiErr *APIError) *ConflictError {
+	e := &ConflictError{APIError: apiErr}
+	if m := existingIDPattern.FindStringSubmatch(apiErr.Message); m != nil {
+		e.ExistingID = m[1]
+	}
+	if m := conflictPropertyPattern.FindStringSubmatch(apiErr.Message); m != nil {
+		e.Property = m[1]
+	}
+	return e
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.APIError
+}
+
+// existingID returns the ID of the existing object when the error is a conflict which reports it.
+func existingID(err error) (string, bool) {
+	var conflictErr *ConflictError
+	if errors.As(err, &conflictErr) && conflictErr.ExistingID != "" {
+		return conflictErr.ExistingID, true
+	}
+	return "", false
+}
+
+// newPropertiesLike returns a new zero value of the type of properties, e.g. a new *Contact for a *Contact,
+// or a map when properties is not a pointer.
+func newPropertiesLike(properties interface{}) interface{} {
+	t := reflect.TypeOf(properties)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return map[string]interface{}{}
+	}
+	return reflect.New(t.Elem()).Interface()
 }
 
 type ErrDetail struct {
@@ -39,6 +119,9 @@ type ErrContext struct {
 	ObjectType     []string `json:"objectType,omitempty"`
 	FromObjectType []string `json:"fromObjectType,omitempty"`
 	ToObjectType   []string `json:"toObjectType,omitempty"`
+	// RequiredScopes are the scopes named by HubSpot along with MISSING_SCOPES,
+	// or else the scopes required by the operation when it is known.
+	RequiredScopes []string `json:"requiredScopes,omitempty"`
 }
 
 type ErrLinks struct {
@@ -49,3 +132,70 @@ type ErrLinks struct {
 func (e APIError) Error() string {
 	return fmt.Sprintf("%d: %s", e.HTTPStatusCode, e.Message)
 }
+
+// Is reports whether the error matches one of the classification errors such as ErrNotFound.
+func (e APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.HTTPStatusCode == http.StatusNotFound || e.Category == ObjectNotFoundError
+	case ErrUnauthorized:
+		return e.HTTPStatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.HTTPStatusCode == http.StatusForbidden
+	case ErrMissingScopes:
+		return e.Category == MissingScopesError
+	case ErrRateLimited:
+		return e.HTTPStatusCode == http.StatusTooManyRequests || e.Category == RateLimitsError
+	case ErrConflict:
+		return e.HTTPStatusCode == http.StatusConflict
+	case ErrValidation:
+		return e.HTTPStatusCode == http.StatusBadRequest || e.Category == ValidationError
+	}
+	return false
+}
+
+// As retrieves the details of the error: *ConflictError for a 409, and *ScopeError for a 403 caused by missing scopes.
+// e.g. var conflictErr *hubspot.ConflictError; errors.As(err, &conflictErr)
+func (e *APIError) As(target interface{}) bool {
+	switch t := target.(type) {
+	case **ConflictError:
+		if e.HTTPStatusCode != http.StatusConflict {
+			return false
+		}
+		*t = newConflictError(e)
+		return true
+	case **ScopeError:
+		if e.Category != MissingScopesError {
+			return false
+		}
+		*t = newScopeError(e)
+		return true
+	}
+	return false
+}
+
+// IsRetryable reports whether the request that failed with the error is worth sending again,
+// that is when HubSpot is rate limiting or temporarily unavailable, or when a network error occurred.
+func IsRetryable(err error) bool {
+	if err == nil || isContextError(err) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return isRetryableStatusCode(apiErr.HTTPStatusCode)
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func isRetryableStatusCode(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	sentinels := []error{
+		hubspot.ErrNotFound,
+		hubspot.ErrUnauthorized,
+		hubspot.ErrForbidden,
+		hubspot.ErrMissingScopes,
+		hubspot.ErrRateLimited,
+		hubspot.ErrConflict,
+		hubspot.ErrValidation,
+	}
+	tests := []struct {
+		name string
+		err  error
+		want []error
+	}{
+		{
+			name: "Not found",
+			err:  &hubspot.APIError{HTTPStatusCode: http.StatusNotFound, Category: hubspot.ObjectNotFoundError},
+			want: []error{hubspot.ErrNotFound},
+		},
+		{
+			name: "Unauthorized",
+			err:  &hubspot.APIError{HTTPStatusCode: http.StatusUnauthorized},
+			want: []error{hubspot.ErrUnauthorized},
+		},
+		{
+			name: "Missing scopes",
+			err:  &hubspot.APIError{HTTPStatusCode: http.StatusForbidden, Category: hubspot.MissingScopesError},
+			want: []error{hubspot.ErrForbidden, hubspot.ErrMissingScopes},
+		},
+		{
+			name: "Rate limited",
+			err:  &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests, Category: hubspot.RateLimitsError},
+			want: []error{hubspot.ErrRateLimited},
+		},
+		{
+			name: "Conflict",
+			err:  &hubspot.APIError{HTTPStatusCode: http.StatusConflict},
+			want: []error{hubspot.ErrConflict},
+		},
+		{
+			name: "Conflict with existing ID",
+			err:  &hubspot.ConflictError{APIError: &hubspot.APIError{HTTPStatusCode: http.StatusConflict}, ExistingID: "12345"},
+			want: []error{hubspot.ErrConflict},
+		},
+		{
+			name: "Validation",
+			err:  &hubspot.APIError{HTTPStatusCode: http.StatusBadRequest, Category: hubspot.ValidationError},
+			want: []error{hubspot.ErrValidation},
+		},
+		{
+			name: "Wrapped after retries",
+			err:  &hubspot.RetryError{Attempts: 3, Err: &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests}},
+			want: []error{hubspot.ErrRateLimited},
+		},
+		{
+			name: "Server error",
+			err:  &hubspot.APIError{HTTPStatusCode: http.StatusInternalServerError},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, sentinel := range sentinels {
+				want := false
+				for _, w := range tt.want {
+					if w == sentinel {
+						want = true
+					}
+				}
+				if got := errors.Is(tt.err, sentinel); want != got {
+					t.Errorf("errors.Is(%v, %v) mismatch: want %t got %t", tt.err, sentinel, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Nil", err: nil, want: false},
+		{name: "Rate limited", err: &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "Service unavailable", err: &hubspot.APIError{HTTPStatusCode: http.StatusServiceUnavailable}, want: true},
+		{name: "Bad request", err: &hubspot.APIError{HTTPStatusCode: http.StatusBadRequest}, want: false},
+		{name: "Wrapped", err: fmt.Errorf("sync: %w", &hubspot.APIError{HTTPStatusCode: http.StatusBadGateway}), want: true},
+		{name: "Network error", err: &url.Error{Op: "Get", URL: "https://api.hubapi.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, want: true},
+		{name: "Canceled", err: &url.Error{Op: "Get", URL: "https://api.hubapi.com", Err: context.Canceled}, want: false},
+		{name: "Other error", err: errors.New("unexpected"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hubspot.IsRetryable(tt.err); tt.want != got {
+				t.Errorf("IsRetryable() result mismatch: want %t got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConflictError_As(t *testing.T) {
+	err := hubspot.CheckResponseError(&http.Response{
+		StatusCode: http.StatusConflict,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"status":"error","message":"A company with the unique property 'company_code' set to 'C-001' already exists. Existing ID: 678","category":"CONFLICT"}`)),
+	})
+
+	// The error is still an APIError for the callers asserting its type.
+	apiErr, ok := err.(*hubspot.APIError)
+	if !ok {
+		t.Fatalf("CheckResponseError() must return an APIError: %#v", err)
+	}
+	var conflictErr *hubspot.ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatal("errors.As() must find the ConflictError")
+	}
+	if conflictErr.ExistingID != "678" || conflictErr.Property != "company_code" {
+		t.Errorf("ConflictError mismatch: want 678 company_code got %s %s", conflictErr.ExistingID, conflictErr.Property)
+	}
+	if conflictErr.APIError != apiErr {
+		t.Error("ConflictError must refer to the APIError")
+	}
+	var unwrapped *hubspot.APIError
+	if !errors.As(conflictErr, &unwrapped) || unwrapped != apiErr {
+		t.Error("errors.As() must find the APIError from the ConflictError")
+	}
+
+	if errors.As(&hubspot.APIError{HTTPStatusCode: http.StatusBadRequest}, &conflictErr) {
+		t.Error("errors.As() must not find a ConflictError in a 400")
+	}
+}
@@ -17,6 +17,8 @@ var (
 	ExportNewCRM          = newCRM
 	ExportNewMarketing    = newMarketing
 	ExportNewConversation = newConversation
+	ExportNewOAuth        = newOAuth
+	ExportNewWebhooks     = newWebhooks
 
 	ExportSetupProperties = (*RequestQueryOption).setupProperties
 
@@ -26,6 +28,13 @@ var (
 	ExportConvertToFormData = (*OAuthConfig).convertToFormData
 	ExportConfigValid       = (*OAuthConfig).valid
 
+	ExportRetryAfter = retryAfter
+	ExportBackoff    = (*RetryConfig).backoff
+
+	ExportParseRateLimitHeader = parseRateLimitHeader
+
+	ExportPathTemplate = pathTemplate
+
 	ExportSetExpiry  = (*OAuthToken).setExpiry
 	ExportTokenValid = (*OAuthToken).valid
 	ExportExpired    = (*OAuthToken).expired
@@ -46,3 +55,11 @@ func (c *Client) ExportSetAPIVersion(version string) {
 func (c *Client) ExportSetBaseURL(url *url.URL) {
 	c.baseURL = url
 }
+
+func (c *Client) ExportGetRetryConfig() *RetryConfig {
+	return c.retryConfig
+}
+
+func (c *Client) ExportGetRateLimiter() *RateLimiter {
+	return c.rateLimiter
+}
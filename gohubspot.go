@@ -2,9 +2,11 @@ package hubspot
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -23,6 +25,11 @@ var (
 	// jsonPattern matches the JSON pattern.
 	// Used to extracts the error details contained in the error message.
 	jsonPattern = regexp.MustCompile(`{[\s\S]*?}`)
+
+	// existingIDPattern and conflictPropertyPattern match the parts of a conflict error message,
+	// e.g. "Contact already exists. Existing ID: 12345".
+	existingIDPattern       = regexp.MustCompile(`Existing ID: (\d+)`)
+	conflictPropertyPattern = regexp.MustCompile(`(?i)property (?:name )?['"]?([a-z0-9_]+)`)
 )
 
 // Client manages communication with the HubSpot API.
@@ -33,10 +40,17 @@ type Client struct {
 	apiVersion string
 
 	authenticator Authenticator
+	retryConfig   *RetryConfig
+	rateLimiter   *RateLimiter
+	middlewares   []Middleware
+	logging       logConfig
+	tracer        Tracer
 
 	CRM          *CRM
 	Marketing    *Marketing
 	Conversation *Conversation
+	OAuth        OAuthService
+	Webhooks     WebhooksService
 }
 
 // RequestPayload is common request structure for HubSpot APIs.
@@ -53,6 +67,8 @@ type ResponseResource struct {
 	CreatedAt    *HsTime       `json:"createdAt,omitempty"`
 	UpdatedAt    *HsTime       `json:"updatedAt,omitempty"`
 	ArchivedAt   *HsTime       `json:"archivedAt,omitempty"`
+	// New tells whether the object has been created by a batch upsert.
+	New bool `json:"new,omitempty"`
 }
 
 // NewClient returns a new HubSpot API client with APIKey or OAuthConfig.
@@ -81,6 +97,8 @@ func NewClient(setAuthMethod AuthMethod, opts ...Option) (*Client, error) {
 	c.CRM = newCRM(c)
 	c.Marketing = newMarketing(c)
 	c.Conversation = newConversation(c)
+	c.OAuth = newOAuth(c)
+	c.Webhooks = newWebhooks(c)
 
 	return c, nil
 }
@@ -88,6 +106,12 @@ func NewClient(setAuthMethod AuthMethod, opts ...Option) (*Client, error) {
 // NewRequest creates an API request.
 // After creating a request, add the authentication information according to the method specified in NewClient().
 func (c *Client) NewRequest(method, path string, body, option interface{}, contentType string) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, path, body, option, contentType)
+}
+
+// NewRequestWithContext creates an API request bound to the given context.
+// The context is used for the authentication process as well, e.g. refreshing an OAuth token.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, path string, body, option interface{}, contentType string) (*http.Request, error) {
 	rel, err := url.Parse(path)
 	if err != nil {
 		return nil, err
@@ -127,7 +151,7 @@ func (c *Client) NewRequest(method, path string, body, option interface{}, conte
 		u.RawQuery = q.Encode()
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(requestBody))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(requestBody))
 	if err != nil {
 		return nil, err
 	}
@@ -149,11 +173,20 @@ func (c *Client) NewRequest(method, path string, body, option interface{}, conte
 // The resource argument is marshalled data returned from HubSpot.
 // If the resource contains a pointer to data, the data will be overwritten with the content of the response.
 func (c *Client) CreateAndDo(method, relPath, contentType string, data, option, resource interface{}) error {
+	return c.CreateAndDoWithContext(context.Background(), method, relPath, contentType, data, option, resource)
+}
+
+// CreateAndDoWithContext performs a web request to HubSpot like CreateAndDo.
+// The request is canceled when the context is canceled or its deadline is exceeded.
+func (c *Client) CreateAndDoWithContext(ctx context.Context, method, relPath, contentType string, data, option, resource interface{}) (err error) {
 	if strings.HasPrefix(relPath, "/") {
 		relPath = strings.TrimLeft(relPath, "/")
 	}
 
-	req, err := c.NewRequest(method, relPath, data, option, contentType)
+	ctx, span := c.startOperationSpan(ctx, method, relPath)
+	defer func() { span.End(err) }()
+
+	req, err := c.NewRequestWithContext(ctx, method, relPath, data, option, contentType)
 	if err != nil {
 		return err
 	}
@@ -167,15 +200,26 @@ func (c *Client) CreateAndDo(method, relPath, contentType string, data, option,
 }
 
 // doGetHeaders executes a request, decoding the response into `v` and also returns any response headers.
-// FIXME: Add optional retry process
+// The request is retried when the client is configured with WithRetryConfig,
+// and sent again with another token when it is rejected with SetPrivateAppTokenSource.
 func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, error) {
-	resp, err := c.HTTPClient.Do(req)
+	resp, attempts, err := c.doWithReauthentication(req)
+	traceResult(req.Context(), resp, attempts)
 	if err != nil {
+		captureResponse(req.Context(), nil, attempts, err)
+		if attempts > 1 {
+			return nil, &RetryError{Attempts: attempts, Err: err}
+		}
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resErr := CheckResponseError(resp); resErr != nil {
+	resErr := withOperationScopes(req.Context(), CheckResponseError(resp))
+	captureResponse(req.Context(), resp, attempts, resErr)
+	if resErr != nil {
+		if attempts > 1 {
+			return nil, &RetryError{Attempts: attempts, Err: resErr}
+		}
 		return nil, resErr
 	}
 
@@ -187,7 +231,46 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 	return resp.Header, nil
 }
 
+// send sends a single attempt of the request through the middlewares.
+// attempt is the number of the attempt, starting from 1.
+func (c *Client) send(req *http.Request, attempt int) (*http.Response, error) {
+	req, span := c.startAttemptSpan(req, attempt)
+	start := timeNow()
+	resp, err := chainMiddlewares(c.doHTTP, c.middlewares)(req)
+	if c.logging.logger != nil {
+		c.logging.log(req, resp, err, attempt, timeNow().Sub(start))
+	}
+	if resp != nil {
+		span.SetAttribute(AttributeStatusCode, resp.StatusCode)
+	}
+	span.End(err)
+	return resp, err
+}
+
+// doHTTP sends the request with the HTTP client, waiting for the rate limiter if there is one.
+func (c *Client) doHTTP(req *http.Request) (*http.Response, error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(req.Context(), req); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			// The URL may contain the API key, which must not leak through the error message.
+			urlErr.URL = redactURL(req.URL)
+		}
+		return nil, err
+	}
+	if c.rateLimiter != nil {
+		c.rateLimiter.Update(req, resp)
+	}
+	return resp, nil
+}
+
 // CheckResponseError checks the response, and in case of error, maps it to the error structure.
+// The error is *APIError, whose details are retrieved with errors.As: *ConflictError for a 409,
+// and *ScopeError for a 403 caused by missing scopes.
 func CheckResponseError(r *http.Response) error {
 	if !isErrorStatusCode(r.StatusCode) {
 		return nil
@@ -196,11 +279,21 @@ func CheckResponseError(r *http.Response) error {
 	hubspotErr := &APIError{
 		HTTPStatusCode: r.StatusCode,
 	}
+	if d, ok := retryAfter(r.Header); ok {
+		hubspotErr.RetryAfter = d
+	}
 
 	if r.Body != nil {
-		if err := json.NewDecoder(r.Body).Decode(hubspotErr); err != nil {
+		raw, err := ioutil.ReadAll(r.Body)
+		if err == nil {
+			err = json.NewDecoder(bytes.NewReader(raw)).Decode(hubspotErr)
+		}
+		if err != nil {
+			// Keep the body since it may tell what went wrong, e.g. an HTML error page from a proxy.
 			return &APIError{
 				HTTPStatusCode: r.StatusCode,
+				RetryAfter:     hubspotErr.RetryAfter,
+				RawBody:        string(raw),
 				Message:        fmt.Sprintf("unable to read response from hubspot: %s", err),
 			}
 		}
@@ -219,6 +312,9 @@ func CheckResponseError(r *http.Response) error {
 				hubspotErr.Details = append(hubspotErr.Details, errDetail)
 			}
 		}
+		if hubspotErr.Category == MissingScopesError {
+			hubspotErr.Context.RequiredScopes = requiredScopes(raw)
+		}
 	}
 
 	return hubspotErr
@@ -231,30 +327,61 @@ func isErrorStatusCode(code int) bool {
 
 // Get performs a GET request for the given path and saves the result in the given resource.
 func (c *Client) Get(path string, resource interface{}, option interface{}) error {
-	return c.CreateAndDo(http.MethodGet, path, MIMETypeJSON, nil, option, resource)
+	return c.GetWithContext(context.Background(), path, resource, option)
+}
+
+// GetWithContext performs a GET request with the given context.
+func (c *Client) GetWithContext(ctx context.Context, path string, resource interface{}, option interface{}) error {
+	return c.CreateAndDoWithContext(ctx, http.MethodGet, path, MIMETypeJSON, nil, option, resource)
 }
 
 // Post performs a POST request for the given path and saves the result in the given resource.
 func (c *Client) Post(path string, data, resource interface{}) error {
-	return c.CreateAndDo(http.MethodPost, path, MIMETypeJSON, data, nil, resource)
+	return c.PostWithContext(context.Background(), path, data, resource)
+}
+
+// PostWithContext performs a POST request with the given context.
+func (c *Client) PostWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, http.MethodPost, path, MIMETypeJSON, data, nil, resource)
 }
 
 // Put performs a PUT request for the given path and saves the result in the given resource.
 func (c *Client) Put(path string, data, resource interface{}) error {
-	return c.CreateAndDo(http.MethodPut, path, MIMETypeJSON, data, nil, resource)
+	return c.PutWithContext(context.Background(), path, data, resource)
+}
+
+// PutWithContext performs a PUT request with the given context.
+func (c *Client) PutWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, http.MethodPut, path, MIMETypeJSON, data, nil, resource)
 }
 
 // Patch performs a PATCH request for the given path and saves the result in the given resource.
 func (c *Client) Patch(path string, data, resource interface{}) error {
-	return c.CreateAndDo(http.MethodPatch, path, MIMETypeJSON, data, nil, resource)
+	return c.PatchWithContext(context.Background(), path, data, resource)
+}
+
+// PatchWithContext performs a PATCH request with the given context.
+func (c *Client) PatchWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, http.MethodPatch, path, MIMETypeJSON, data, nil, resource)
 }
 
 // Delete performs a DELETE request for the given path.
 func (c *Client) Delete(path string, option interface{}) error {
-	return c.CreateAndDo(http.MethodDelete, path, MIMETypeJSON, nil, option, nil)
+	return c.DeleteWithContext(context.Background(), path, option)
+}
+
+// DeleteWithContext performs a DELETE request with the given context.
+func (c *Client) DeleteWithContext(ctx context.Context, path string, option interface{}) error {
+	return c.CreateAndDoWithContext(ctx, http.MethodDelete, path, MIMETypeJSON, nil, option, nil)
 }
 
+// PostMultipart performs a multipart POST request for the given path and saves the result in the given resource.
 func (c *Client) PostMultipart(path, boundary string, data, resource interface{}) error {
+	return c.PostMultipartWithContext(context.Background(), path, boundary, data, resource)
+}
+
+// PostMultipartWithContext performs a multipart POST request with the given context.
+func (c *Client) PostMultipartWithContext(ctx context.Context, path, boundary string, data, resource interface{}) error {
 	mimeType := fmt.Sprintf("%s; boundary=%s", MIMETypeFormData, boundary)
-	return c.CreateAndDo(http.MethodPost, path, mimeType, data, nil, resource)
+	return c.CreateAndDoWithContext(ctx, http.MethodPost, path, mimeType, data, nil, resource)
 }
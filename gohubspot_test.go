@@ -2,6 +2,7 @@ package hubspot_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io/ioutil"
 	"net/http"
@@ -153,6 +154,8 @@ func TestNewClient(t *testing.T) {
 				want.CRM = hubspot.ExportNewCRM(want)
 				want.Marketing = hubspot.ExportNewMarketing(want)
 				want.Conversation = hubspot.ExportNewConversation(want)
+				want.OAuth = hubspot.ExportNewOAuth(want)
+				want.Webhooks = hubspot.ExportNewWebhooks(want)
 				tt.settings.authMethod(want)
 			}
 
@@ -582,6 +585,68 @@ func TestCheckResponseError(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Response Conflict",
+			args: args{
+				r: &http.Response{
+					StatusCode: http.StatusConflict,
+					Body:       ioutil.NopCloser(bytes.NewBuffer([]byte(`{"status":"error","message":"Contact already exists. Existing ID: 12345","correlationId":"aeb5f871-7f07-4993-9211-075dc63e7cbf","category":"CONFLICT"}`))),
+				},
+			},
+			wantErr: &hubspot.APIError{
+				HTTPStatusCode: http.StatusConflict,
+				Status:         "error",
+				Message:        "Contact already exists. Existing ID: 12345",
+				CorrelationID:  "aeb5f871-7f07-4993-9211-075dc63e7cbf",
+				Category:       "CONFLICT",
+			},
+		},
+		{
+			name: "Response Conflict on unique property",
+			args: args{
+				r: &http.Response{
+					StatusCode: http.StatusConflict,
+					Body:       ioutil.NopCloser(bytes.NewBuffer([]byte(`{"status":"error","message":"A company with the unique property 'company_code' set to 'C-001' already exists. Existing ID: 678","category":"CONFLICT"}`))),
+				},
+			},
+			wantErr: &hubspot.APIError{
+				HTTPStatusCode: http.StatusConflict,
+				Status:         "error",
+				Message:        "A company with the unique property 'company_code' set to 'C-001' already exists. Existing ID: 678",
+				Category:       "CONFLICT",
+			},
+		},
+		{
+			name: "Response BadGateway with HTML body",
+			args: args{
+				r: &http.Response{
+					StatusCode: http.StatusBadGateway,
+					Body:       ioutil.NopCloser(bytes.NewBuffer([]byte(`<html><body>502 Bad Gateway</body></html>`))),
+				},
+			},
+			wantErr: &hubspot.APIError{
+				HTTPStatusCode: http.StatusBadGateway,
+				Message:        "unable to read response from hubspot: invalid character '<' looking for beginning of value",
+				RawBody:        `<html><body>502 Bad Gateway</body></html>`,
+			},
+		},
+		{
+			name: "Response TooManyRequests with Retry-After",
+			args: args{
+				r: &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     http.Header{"Retry-After": []string{"10"}},
+					Body:       ioutil.NopCloser(bytes.NewBuffer([]byte(`{"status":"error","message":"You have reached your secondly limit.","category":"RATE_LIMITS"}`))),
+				},
+			},
+			wantErr: &hubspot.APIError{
+				HTTPStatusCode: http.StatusTooManyRequests,
+				Status:         "error",
+				Message:        "You have reached your secondly limit.",
+				Category:       hubspot.RateLimitsError,
+				RetryAfter:     10 * time.Second,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -592,3 +657,56 @@ func TestCheckResponseError(t *testing.T) {
 		})
 	}
 }
+
+type contextTestKey struct{}
+
+type contextRoundTripper func(req *http.Request) (*http.Response, error)
+
+func (f contextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClient_CreateAndDoWithContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{
+			name:    "Context is propagated to the request",
+			ctx:     context.WithValue(context.Background(), contextTestKey{}, "value"),
+			wantErr: nil,
+		},
+		{
+			name:    "Canceled context aborts the request",
+			ctx:     canceled,
+			wantErr: context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(&http.Client{
+				Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+					if err := req.Context().Err(); err != nil {
+						return nil, err
+					}
+					if got := req.Context().Value(contextTestKey{}); got != "value" {
+						t.Errorf("request context value mismatch: want value got %v", got)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+					}, nil
+				}),
+			}))
+
+			err := c.CreateAndDoWithContext(tt.ctx, http.MethodGet, "objects/test", hubspot.MIMETypeJSON, nil, nil, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateAndDoWithContext() error mismatch: want %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package hubspot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// redacted replaces the secrets and masked values in the log events.
+	redacted = "REDACTED"
+
+	// maxLoggedBodySize is the maximum size of a body written in a log event.
+	maxLoggedBodySize = 64 << 10
+)
+
+// DefaultMaskedProperties are the properties masked in the logged bodies when WithLogBodies is used.
+var DefaultMaskedProperties = []string{
+	"email",
+	"phone",
+	"mobilephone",
+	"fax",
+	"firstname",
+	"lastname",
+	"address",
+	"hs_additional_emails",
+}
+
+// secretParameters are always redacted from the logged URLs and bodies.
+var secretParameters = map[string]bool{
+	"hapikey":       true,
+	"client_secret": true,
+	"refresh_token": true,
+	"access_token":  true,
+	"code":          true,
+	"tokenkey":      true,
+}
+
+// tokenPathSegments are the path segments followed by a token, e.g. oauth/v1/access-tokens/{token}.
+var tokenPathSegments = map[string]bool{
+	"access-tokens":  true,
+	"refresh-tokens": true,
+}
+
+// idSegmentPattern matches the path segments which are replaced by a placeholder in the path template,
+// such as record IDs, custom object type IDs and email addresses used as idProperty.
+var idSegmentPattern = regexp.MustCompile(`^(\d+(-\d+)?|.*@.*)$`)
+
+// LogEvent is a structured log event emitted for every attempt of a request.
+// Secrets are always redacted. Bodies are set only when the client is configured with WithLogBodies.
+type LogEvent struct {
+	Method string
+	// PathTemplate is the request path where the IDs are replaced by {id}, e.g. crm/v3/objects/contacts/{id}.
+	PathTemplate string
+	// URL is the request URL where the secrets are redacted.
+	URL           string
+	StatusCode    int
+	Latency       time.Duration
+	Attempt       int
+	CorrelationID string
+	Err           error
+	RequestHeader http.Header
+	RequestBody   string
+	ResponseBody  string
+}
+
+// Logger receives the log events of a Client.
+type Logger interface {
+	Log(ctx context.Context, event *LogEvent)
+}
+
+// LoggerFunc is an adapter to use an ordinary function as a Logger.
+type LoggerFunc func(ctx context.Context, event *LogEvent)
+
+func (f LoggerFunc) Log(ctx context.Context, event *LogEvent) {
+	f(ctx, event)
+}
+
+// NewStdLogger returns a Logger writing the log events to l with the key=value format.
+func NewStdLogger(l *log.Logger) Logger {
+	return LoggerFunc(func(_ context.Context, e *LogEvent) {
+		msg := fmt.Sprintf("method=%s path=%s status=%d latency=%s attempt=%d correlation_id=%s",
+			e.Method, e.PathTemplate, e.StatusCode, e.Latency, e.Attempt, e.CorrelationID)
+		if e.Err != nil {
+			msg += fmt.Sprintf(" error=%q", e.Err)
+		}
+		if e.RequestBody != "" {
+			msg += fmt.Sprintf(" request_body=%q", e.RequestBody)
+		}
+		if e.ResponseBody != "" {
+			msg += fmt.Sprintf(" response_body=%q", e.ResponseBody)
+		}
+		l.Println(msg)
+	})
+}
+
+// logConfig is the logging configuration of a Client.
+type logConfig struct {
+	logger Logger
+	bodies bool
+	masked map[string]bool
+}
+
+// log emits the log event of an attempt.
+func (lc *logConfig) log(req *http.Request, resp *http.Response, err error, attempt int, latency time.Duration) {
+	e := &LogEvent{
+		Method:        req.Method,
+		PathTemplate:  pathTemplate(req.URL.Path),
+		URL:           redactURL(req.URL),
+		Attempt:       attempt,
+		Latency:       latency,
+		Err:           err,
+		RequestHeader: redactHeader(req.Header),
+	}
+	if resp != nil {
+		e.StatusCode = resp.StatusCode
+		e.CorrelationID = resp.Header.Get(headerCorrelationID)
+	}
+
+	if lc.bodies {
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				b, _ := ioutil.ReadAll(body)
+				body.Close()
+				e.RequestBody = lc.maskBody(req.Header.Get("Content-Type"), b)
+			}
+		}
+		if resp != nil && resp.Body != nil {
+			b, readErr := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			// Give the body back to the caller.
+			resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+			if readErr == nil {
+				e.ResponseBody = lc.maskBody(resp.Header.Get("Content-Type"), b)
+			}
+		}
+	}
+
+	lc.logger.Log(req.Context(), e)
+}
+
+// maskBody returns the body to log, where the secrets are redacted and the masked properties are replaced.
+// Bodies which are neither JSON nor form data are omitted since their content cannot be masked.
+func (lc *logConfig) maskBody(contentType string, b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	var masked string
+	switch {
+	case strings.HasPrefix(contentType, MIMETypeJSON) || json.Valid(b):
+		var v interface{}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return fmt.Sprintf("[%d bytes omitted]", len(b))
+		}
+		mb, err := json.Marshal(lc.maskJSON(v))
+		if err != nil {
+			return fmt.Sprintf("[%d bytes omitted]", len(b))
+		}
+		masked = string(mb)
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		form, err := url.ParseQuery(string(b))
+		if err != nil {
+			return fmt.Sprintf("[%d bytes omitted]", len(b))
+		}
+		masked = lc.maskValues(form).Encode()
+	default:
+		return fmt.Sprintf("[%d bytes omitted]", len(b))
+	}
+
+	if len(masked) > maxLoggedBodySize {
+		return masked[:maxLoggedBodySize] + "...(truncated)"
+	}
+	return masked
+}
+
+func (lc *logConfig) maskJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			key := strings.ToLower(k)
+			if secretParameters[key] || lc.masked[key] {
+				t[k] = redacted
+				continue
+			}
+			if key == "propertyname" || key == "name" {
+				// Search filters and property changes carry the value next to the property name.
+				if name, ok := val.(string); ok && lc.masked[strings.ToLower(name)] {
+					for _, valueKey := range []string{"value", "values", "highValue", "propertyValue"} {
+						if _, ok := t[valueKey]; ok {
+							t[valueKey] = redacted
+						}
+					}
+				}
+			}
+			t[k] = lc.maskJSON(t[k])
+		}
+		return t
+	case []interface{}:
+		for i := range t {
+			t[i] = lc.maskJSON(t[i])
+		}
+		return t
+	}
+	return v
+}
+
+func (lc *logConfig) maskValues(values url.Values) url.Values {
+	for k := range values {
+		key := strings.ToLower(k)
+		if secretParameters[key] || lc.masked[key] {
+			values[k] = []string{redacted}
+		}
+	}
+	return values
+}
+
+// redactURL returns the URL where the secret query parameters such as hapikey and the tokens in the path are redacted.
+func redactURL(u *url.URL) string {
+	ru := *u
+	if path, ok := redactPathTokens(ru.Path, redacted); ok {
+		ru.Path = path
+		ru.RawPath = ""
+	}
+	q := ru.Query()
+	for k := range q {
+		if secretParameters[strings.ToLower(k)] {
+			q[k] = []string{redacted}
+		}
+	}
+	ru.RawQuery = q.Encode()
+	ru.User = nil
+	return ru.String()
+}
+
+// redactHeader returns a copy of the header where the credentials are redacted.
+func redactHeader(h http.Header) http.Header {
+	rh := h.Clone()
+	for _, k := range []string{"Authorization", "Cookie", "Proxy-Authorization"} {
+		if rh.Get(k) != "" {
+			rh.Set(k, redacted)
+		}
+	}
+	return rh
+}
+
+// redactPathTokens replaces the tokens in the path by replacement, and reports whether the path contained any.
+func redactPathTokens(path, replacement string) (string, bool) {
+	segments := strings.Split(path, "/")
+	found := false
+	for i := 1; i < len(segments); i++ {
+		if tokenPathSegments[segments[i-1]] && segments[i] != "" {
+			segments[i] = replacement
+			found = true
+		}
+	}
+	return strings.Join(segments, "/"), found
+}
+
+// pathTemplate replaces the IDs and tokens of the path by a placeholder, so that requests to the same endpoint can be grouped.
+func pathTemplate(path string) string {
+	path, _ = redactPathTokens(path, "{token}")
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, s := range segments {
+		if idSegmentPattern.MatchString(s) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// newMaskedProperties returns the set of the properties to mask.
+func newMaskedProperties(properties []string) map[string]bool {
+	masked := make(map[string]bool, len(DefaultMaskedProperties)+len(properties))
+	for _, p := range append(append([]string{}, DefaultMaskedProperties...), properties...) {
+		masked[strings.ToLower(p)] = true
+	}
+	return masked
+}
@@ -0,0 +1,137 @@
+package hubspot_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithLogger(t *testing.T) {
+	tests := []struct {
+		name       string
+		authMethod hubspot.AuthMethod
+		want       *hubspot.LogEvent
+	}{
+		{
+			name:       "Redact private app token",
+			authMethod: hubspot.SetPrivateAppToken("secret-token"),
+			want: &hubspot.LogEvent{
+				Method:        http.MethodGet,
+				PathTemplate:  "crm/v3/objects/contacts/{id}",
+				URL:           "https://api.hubapi.com/crm/v3/objects/contacts/12345",
+				StatusCode:    http.StatusOK,
+				Attempt:       1,
+				CorrelationID: "aeb5f871-7f07-4993-9211-075dc63e7cbf",
+				RequestHeader: http.Header{
+					"Authorization": []string{"REDACTED"},
+					"Content-Type":  []string{"application/json"},
+				},
+			},
+		},
+		{
+			name:       "Redact api key",
+			authMethod: hubspot.SetAPIKey("secret-key"),
+			want: &hubspot.LogEvent{
+				Method:        http.MethodGet,
+				PathTemplate:  "crm/v3/objects/contacts/{id}",
+				URL:           "https://api.hubapi.com/crm/v3/objects/contacts/12345?hapikey=REDACTED",
+				StatusCode:    http.StatusOK,
+				Attempt:       1,
+				CorrelationID: "aeb5f871-7f07-4993-9211-075dc63e7cbf",
+				RequestHeader: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *hubspot.LogEvent
+			c, _ := hubspot.NewClient(tt.authMethod,
+				hubspot.WithHTTPClient(hubspot.NewMockHTTPClient(&hubspot.MockConfig{
+					Status: http.StatusOK,
+					Header: http.Header{"X-Hubspot-Correlation-Id": []string{"aeb5f871-7f07-4993-9211-075dc63e7cbf"}},
+					Body:   []byte(`{}`),
+				})),
+				hubspot.WithLogger(hubspot.LoggerFunc(func(_ context.Context, e *hubspot.LogEvent) {
+					got = e
+				})),
+			)
+
+			if err := c.Get("crm/v3/objects/contacts/12345", nil, nil); err != nil {
+				t.Fatalf("Get() error: %s", err)
+			}
+			got.Latency = 0
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LogEvent mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithLogBodies(t *testing.T) {
+	var got *hubspot.LogEvent
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"),
+		hubspot.WithHTTPClient(hubspot.NewMockHTTPClient(&hubspot.MockConfig{
+			Status: http.StatusOK,
+			Header: http.Header{"Content-Type": []string{"application/json"}},
+			Body:   []byte(`{"results":[{"id":"1","properties":{"email":"bcooper@example.com","company":"Belong"}}]}`),
+		})),
+		hubspot.WithLogger(hubspot.LoggerFunc(func(_ context.Context, e *hubspot.LogEvent) {
+			got = e
+		})),
+		hubspot.WithLogBodies("custom_secret"),
+	)
+
+	req := map[string]interface{}{
+		"filterGroups": []interface{}{
+			map[string]interface{}{
+				"filters": []interface{}{
+					map[string]interface{}{"propertyName": "email", "operator": "EQ", "value": "bcooper@example.com"},
+				},
+			},
+		},
+		"properties": map[string]interface{}{"custom_secret": "abc", "company": "Belong"},
+	}
+	var res map[string]interface{}
+	if err := c.Post("crm/v3/objects/contacts/search", req, &res); err != nil {
+		t.Fatalf("Post() error: %s", err)
+	}
+
+	wantRequestBody := `{"filterGroups":[{"filters":[{"operator":"EQ","propertyName":"email","value":"REDACTED"}]}],"properties":{"company":"Belong","custom_secret":"REDACTED"}}`
+	if diff := cmp.Diff(wantRequestBody, got.RequestBody); diff != "" {
+		t.Errorf("RequestBody mismatch (-want +got):%s", diff)
+	}
+	wantResponseBody := `{"results":[{"id":"1","properties":{"company":"Belong","email":"REDACTED"}}]}`
+	if diff := cmp.Diff(wantResponseBody, got.ResponseBody); diff != "" {
+		t.Errorf("ResponseBody mismatch (-want +got):%s", diff)
+	}
+	// The caller still receives the original body.
+	if res["results"] == nil {
+		t.Errorf("Post() response must be decoded after logging: got %v", res)
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/crm/v3/objects/contacts/12345", want: "crm/v3/objects/contacts/{id}"},
+		{path: "crm/v3/objects/2-3456/789/associations/contacts/12", want: "crm/v3/objects/{id}/{id}/associations/contacts/{id}"},
+		{path: "crm/v3/objects/contacts/bcooper@example.com", want: "crm/v3/objects/contacts/{id}"},
+		{path: "crm/v3/objects/contacts/search", want: "crm/v3/objects/contacts/search"},
+		{path: "oauth/v1/access-tokens/CJSP5qf1KhICAQEYs-gDIIGOBii1hQIyGQAf3xBKmlwHjX7OIpuIFEavB2-qYAGQsF4", want: "oauth/v1/access-tokens/{token}"},
+		{path: "oauth/v1/refresh-tokens/6f18f21e-a743-4509-b57b-5a2b4d7c1b3a", want: "oauth/v1/refresh-tokens/{token}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := hubspot.ExportPathTemplate(tt.path); tt.want != got {
+				t.Errorf("pathTemplate() result mismatch: want %s got %s", tt.want, got)
+			}
+		})
+	}
+}
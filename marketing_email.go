@@ -1,6 +1,7 @@
 package hubspot
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/belong-inc/go-hubspot/legacy"
@@ -24,7 +25,9 @@ type (
 // https://legacydocs.hubspot.com/docs/methods/cms_email/get-the-statistics-for-a-marketing-email.
 type MarketingEmailService interface {
 	GetStatistics(emailID int, statistics interface{}) (*ResponseResource, error)
+	GetStatisticsWithContext(ctx context.Context, emailID int, statistics interface{}) (*ResponseResource, error)
 	ListStatistics(statistics interface{}, option *BulkRequestQueryOption) (*ResponseResource, error)
+	ListStatisticsWithContext(ctx context.Context, statistics interface{}, option *BulkRequestQueryOption) (*ResponseResource, error)
 }
 
 type MarketingEmailOp struct {
@@ -45,7 +48,13 @@ func NewMarketingEmail(client *Client) MarketingEmailService {
 
 // GetStatistics get a Statistics for given emailID.
 func (m *MarketingEmailOp) GetStatistics(emailID int, resource interface{}) (*ResponseResource, error) {
-	if err := m.client.Get(m.legacyAPIHelper.GetStatisticsPath()+fmt.Sprintf("/%d", emailID), resource, nil); err != nil {
+	return m.GetStatisticsWithContext(context.Background(), emailID, resource)
+}
+
+// GetStatisticsWithContext get a Statistics for given emailID with the given context.
+func (m *MarketingEmailOp) GetStatisticsWithContext(ctx context.Context, emailID int, resource interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "Marketing.Email.GetStatistics", "")
+	if err := m.client.GetWithContext(ctx, m.legacyAPIHelper.GetStatisticsPath()+fmt.Sprintf("/%d", emailID), resource, nil); err != nil {
 		return nil, err
 	}
 	return &ResponseResource{Properties: resource}, nil
@@ -53,7 +62,13 @@ func (m *MarketingEmailOp) GetStatistics(emailID int, resource interface{}) (*Re
 
 // ListStatistics get a list of Statistics.
 func (m *MarketingEmailOp) ListStatistics(resource interface{}, option *BulkRequestQueryOption) (*ResponseResource, error) {
-	if err := m.client.Get(m.legacyAPIHelper.GetStatisticsPath(), resource, option); err != nil {
+	return m.ListStatisticsWithContext(context.Background(), resource, option)
+}
+
+// ListStatisticsWithContext get a list of Statistics with the given context.
+func (m *MarketingEmailOp) ListStatisticsWithContext(ctx context.Context, resource interface{}, option *BulkRequestQueryOption) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "Marketing.Email.ListStatistics", "")
+	if err := m.client.GetWithContext(ctx, m.legacyAPIHelper.GetStatisticsPath(), resource, option); err != nil {
 		return nil, err
 	}
 	return &ResponseResource{Properties: resource}, nil
@@ -1,6 +1,9 @@
 package hubspot
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 const transactionalBasePath = "transactional"
 
@@ -8,6 +11,7 @@ const transactionalBasePath = "transactional"
 // Reference: https://developers.hubspot.com/docs/api/marketing/transactional-emails
 type TransactionalService interface {
 	SendSingleEmail(props *SendSingleEmailProperties) (*SendSingleEmailResponse, error)
+	SendSingleEmailWithContext(ctx context.Context, props *SendSingleEmailProperties) (*SendSingleEmailResponse, error)
 }
 
 // TransactionalServiceOp provides the default implementation of TransactionService.
@@ -41,8 +45,13 @@ type SendSingleEmailResponse struct {
 }
 
 func (s *TransactionalServiceOp) SendSingleEmail(props *SendSingleEmailProperties) (*SendSingleEmailResponse, error) {
+	return s.SendSingleEmailWithContext(context.Background(), props)
+}
+
+func (s *TransactionalServiceOp) SendSingleEmailWithContext(ctx context.Context, props *SendSingleEmailProperties) (*SendSingleEmailResponse, error) {
+	ctx = withOperation(ctx, "Marketing.Transactional.SendSingleEmail", "")
 	resource := &SendSingleEmailResponse{}
-	if err := s.client.Post(fmt.Sprintf("%s/single-email/send", s.transactionalPath), props, resource); err != nil {
+	if err := s.client.PostWithContext(ctx, fmt.Sprintf("%s/single-email/send", s.transactionalPath), props, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -0,0 +1,32 @@
+package hubspot
+
+import "net/http"
+
+// DoFunc sends a request to HubSpot and returns its response.
+type DoFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the execution of the requests sent by a Client.
+// A middleware receives the request once the authentication has been set, and can mutate it before calling next.
+// It observes the raw response and error returned by next before the error response is decoded,
+// and it may also answer without calling next at all, e.g. to serve a cached response or to inject a fault.
+// Middlewares run for every attempt when the request is retried.
+//
+//	func auditMiddleware(next hubspot.DoFunc) hubspot.DoFunc {
+//		return func(req *http.Request) (*http.Response, error) {
+//			req.Header.Set("X-Request-Source", "sync-worker")
+//			resp, err := next(req)
+//			if err == nil {
+//				log.Println(req.Method, req.URL.Path, resp.StatusCode)
+//			}
+//			return resp, err
+//		}
+//	}
+type Middleware func(next DoFunc) DoFunc
+
+// chainMiddlewares wraps do with the middlewares, the first one being the outermost.
+func chainMiddlewares(do DoFunc, middlewares []Middleware) DoFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		do = middlewares[i](do)
+	}
+	return do
+}
@@ -0,0 +1,94 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var calls []string
+	record := func(name string) hubspot.Middleware {
+		return func(next hubspot.DoFunc) hubspot.DoFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" request "+req.Header.Get("Authorization"))
+				req.Header.Add("X-Middleware", name)
+				resp, err := next(req)
+				if err == nil {
+					calls = append(calls, name+" response "+http.StatusText(resp.StatusCode))
+				}
+				return resp, err
+			}
+		}
+	}
+
+	var gotHeader http.Header
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"),
+		hubspot.WithHTTPClient(&http.Client{
+			Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+				gotHeader = req.Header
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"message":"not found"}`)),
+				}, nil
+			}),
+		}),
+		hubspot.WithMiddleware(record("first")),
+		hubspot.WithMiddleware(record("second")),
+	)
+
+	err := c.Get("objects/test", nil, nil)
+	if diff := cmp.Diff(&hubspot.APIError{HTTPStatusCode: http.StatusNotFound, Message: "not found"}, err); diff != "" {
+		t.Errorf("Get() error mismatch (-want +got):%s", diff)
+	}
+
+	wantCalls := []string{
+		"first request Bearer token",
+		"second request Bearer token",
+		"second response Not Found",
+		"first response Not Found",
+	}
+	if diff := cmp.Diff(wantCalls, calls); diff != "" {
+		t.Errorf("middleware calls mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff([]string{"first", "second"}, gotHeader.Values("X-Middleware")); diff != "" {
+		t.Errorf("request header mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestWithMiddleware_ShortCircuit(t *testing.T) {
+	var sent bool
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"),
+		hubspot.WithHTTPClient(&http.Client{
+			Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+				sent = true
+				return nil, nil
+			}),
+		}),
+		hubspot.WithMiddleware(func(next hubspot.DoFunc) hubspot.DoFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":"cached"}`)),
+				}, nil
+			}
+		}),
+	)
+
+	var got struct {
+		ID string `json:"id"`
+	}
+	if err := c.Get("objects/test", &got, nil); err != nil {
+		t.Fatalf("Get() error: %s", err)
+	}
+	if got.ID != "cached" {
+		t.Errorf("Get() result mismatch: want cached got %s", got.ID)
+	}
+	if sent {
+		t.Error("the request must not be sent when a middleware answers it")
+	}
+}
@@ -69,3 +69,22 @@ func MockTimeNow() func() {
 	timeNow = func() time.Time { return mockTime }
 	return func() { timeNow = time.Now }
 }
+
+func MockTimeNowFunc(now func() time.Time) func() {
+	timeNow = now
+	return func() { timeNow = time.Now }
+}
+
+// Retry
+
+func MockJitter() func() {
+	jitter = func(d time.Duration) time.Duration { return d }
+	return func() { jitter = defaultJitter }
+}
+
+// Search
+
+func MockMaxSearchResults(n int) func() {
+	maxSearchResults = n
+	return func() { maxSearchResults = 10000 }
+}
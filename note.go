@@ -1,5 +1,7 @@
 package hubspot
 
+import "context"
+
 const (
 	noteBasePath = "notes"
 )
@@ -10,10 +12,15 @@ const (
 // Reference: https://developers.hubspot.com/docs/api/crm/notes
 type NoteService interface {
 	Get(noteID string, note interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	GetWithContext(ctx context.Context, noteID string, note interface{}, option *RequestQueryOption) (*ResponseResource, error)
 	Create(note interface{}) (*ResponseResource, error)
+	CreateWithContext(ctx context.Context, note interface{}) (*ResponseResource, error)
 	Update(noteID string, note interface{}) (*ResponseResource, error)
+	UpdateWithContext(ctx context.Context, noteID string, note interface{}) (*ResponseResource, error)
 	Delete(noteID string) error
+	DeleteWithContext(ctx context.Context, noteID string) error
 	AssociateAnotherObj(noteID string, conf *AssociationConfig) (*ResponseResource, error)
+	AssociateAnotherObjWithContext(ctx context.Context, noteID string, conf *AssociationConfig) (*ResponseResource, error)
 }
 
 // NoteServiceOp handles communication with the note related methods of the HubSpot API.
@@ -88,8 +95,14 @@ var defaultNoteFields = []string{
 // If you specify a non-existent field, it will be ignored.
 // e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
 func (s *NoteServiceOp) Get(noteID string, note interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	return s.GetWithContext(context.Background(), noteID, note, option)
+}
+
+// GetWithContext gets a note with the given context.
+func (s *NoteServiceOp) GetWithContext(ctx context.Context, noteID string, note interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Note.Get", noteBasePath)
 	resource := &ResponseResource{Properties: note}
-	if err := s.client.Get(s.notePath+"/"+noteID, resource, option.setupProperties(defaultNoteFields)); err != nil {
+	if err := s.client.GetWithContext(ctx, s.notePath+"/"+noteID, resource, option.setupProperties(defaultNoteFields)); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -99,9 +112,15 @@ func (s *NoteServiceOp) Get(noteID string, note interface{}, option *RequestQuer
 // In order to bind the created content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Note in your own structure.
 func (s *NoteServiceOp) Create(note interface{}) (*ResponseResource, error) {
+	return s.CreateWithContext(context.Background(), note)
+}
+
+// CreateWithContext creates a new note with the given context.
+func (s *NoteServiceOp) CreateWithContext(ctx context.Context, note interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Note.Create", noteBasePath)
 	req := &RequestPayload{Properties: note}
 	resource := &ResponseResource{Properties: note}
-	if err := s.client.Post(s.notePath, req, resource); err != nil {
+	if err := s.client.PostWithContext(ctx, s.notePath, req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -111,9 +130,15 @@ func (s *NoteServiceOp) Create(note interface{}) (*ResponseResource, error) {
 // In order to bind the updated content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Note in your own structure.
 func (s *NoteServiceOp) Update(noteID string, note interface{}) (*ResponseResource, error) {
+	return s.UpdateWithContext(context.Background(), noteID, note)
+}
+
+// UpdateWithContext updates a note with the given context.
+func (s *NoteServiceOp) UpdateWithContext(ctx context.Context, noteID string, note interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Note.Update", noteBasePath)
 	req := &RequestPayload{Properties: note}
 	resource := &ResponseResource{Properties: note}
-	if err := s.client.Patch(s.notePath+"/"+noteID, req, resource); err != nil {
+	if err := s.client.PatchWithContext(ctx, s.notePath+"/"+noteID, req, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -121,14 +146,26 @@ func (s *NoteServiceOp) Update(noteID string, note interface{}) (*ResponseResour
 
 // Delete deletes a note.
 func (s *NoteServiceOp) Delete(noteID string) error {
-	return s.client.Delete(s.notePath+"/"+noteID, nil)
+	return s.DeleteWithContext(context.Background(), noteID)
+}
+
+// DeleteWithContext deletes a note with the given context.
+func (s *NoteServiceOp) DeleteWithContext(ctx context.Context, noteID string) error {
+	ctx = withOperation(ctx, "CRM.Note.Delete", noteBasePath)
+	return s.client.DeleteWithContext(ctx, s.notePath+"/"+noteID, nil)
 }
 
 // AssociateAnotherObj associates Note with another HubSpot objects.
 // If you want to associate a custom object, please use a defined value in HubSpot.
 func (s *NoteServiceOp) AssociateAnotherObj(noteID string, conf *AssociationConfig) (*ResponseResource, error) {
+	return s.AssociateAnotherObjWithContext(context.Background(), noteID, conf)
+}
+
+// AssociateAnotherObjWithContext associates Note with another HubSpot objects using the given context.
+func (s *NoteServiceOp) AssociateAnotherObjWithContext(ctx context.Context, noteID string, conf *AssociationConfig) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Note.AssociateAnotherObj", noteBasePath)
 	resource := &ResponseResource{Properties: &Note{}}
-	if err := s.client.Put(s.notePath+"/"+noteID+"/"+conf.makeAssociationPath(), nil, resource); err != nil {
+	if err := s.client.PutWithContext(ctx, s.notePath+"/"+noteID+"/"+conf.makeAssociationPath(), nil, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
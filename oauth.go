@@ -1,6 +1,7 @@
 package hubspot
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,35 +9,157 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	oauthTokenPath = "oauth/v1/token"
 
-	GrantTypeRefreshToken = "refresh_token"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeAuthorizationCode = "authorization_code"
 )
 
 type OAuthTokenRetriever interface {
 	RetrieveToken() (*OAuthToken, error)
 }
 
+// OAuthTokenContextRetriever is an OAuthTokenRetriever that can honour a context while retrieving a token.
+// OAuth uses it with the context of the request being authenticated when the retriever implements it.
+type OAuthTokenContextRetriever interface {
+	OAuthTokenRetriever
+	RetrieveTokenWithContext(ctx context.Context) (*OAuthToken, error)
+}
+
+// OAuthTokenManager retrieves the OAuth access token, refreshing it when needed.
+// It is safe for concurrent use: only one refresh is sent to HubSpot at a time and the other callers wait for it.
+// The token is also refreshed in the background shortly before it expires.
+// When HubSpot rotates the refresh token, the new one is set to Config.
 type OAuthTokenManager struct {
 	oauthPath string
 
 	HTTPClient *http.Client
 	Config     *OAuthConfig
 	Token      *OAuthToken
+	// Store persists the token when set. See TokenStore.
+	Store TokenStore
+
+	// mu guards Config, Token, inflight and the last failure.
+	mu       sync.Mutex
+	inflight *tokenRefresh
+	// failedAt and failure are the time and the error of the last failed refresh.
+	failedAt time.Time
+	failure  error
 }
 
-var _ OAuthTokenRetriever = (*OAuthTokenManager)(nil)
+var _ OAuthTokenContextRetriever = (*OAuthTokenManager)(nil)
+
+const (
+	// tokenRefreshAhead is how long before the expiry the token is refreshed in the background.
+	tokenRefreshAhead = 5 * time.Minute
+	// tokenRefreshBackoff is how long no refresh is sent after one has failed.
+	tokenRefreshBackoff = 30 * time.Second
+)
+
+// tokenRefresh is a refresh in flight, shared by the callers waiting for it.
+type tokenRefresh struct {
+	done  chan struct{}
+	token *OAuthToken
+	err   error
+}
 
 func (otm *OAuthTokenManager) RetrieveToken() (*OAuthToken, error) {
-	if otm.Token.valid() {
-		return otm.Token, nil
+	return otm.RetrieveTokenWithContext(context.Background())
+}
+
+// RetrieveTokenWithContext returns a valid token, refreshing it with the given context if needed.
+// After a refresh has failed, no other refresh is sent for a while, and the error is returned until the token is refreshed.
+func (otm *OAuthTokenManager) RetrieveTokenWithContext(ctx context.Context) (*OAuthToken, error) {
+	for {
+		otm.mu.Lock()
+		backingOff := otm.failure != nil && timeNow().Sub(otm.failedAt) < tokenRefreshBackoff
+		if otm.Token.valid() {
+			token := otm.Token
+			if otm.Token.expiresWithin(tokenRefreshAhead) && !backingOff {
+				otm.startRefresh(context.Background())
+			}
+			otm.mu.Unlock()
+			return token, nil
+		}
+		if backingOff && otm.inflight == nil {
+			err := otm.failure
+			otm.mu.Unlock()
+			return nil, fmt.Errorf("the token refresh has failed recently: %w", err)
+		}
+		call := otm.startRefresh(ctx)
+		otm.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if isContextError(call.err) && ctx.Err() == nil {
+			// The refresh has been canceled by the caller which started it, so try again with this context.
+			continue
+		}
+		return call.token, call.err
 	}
+}
 
-	tokenByte, err := otm.fetchTokenFromHubSpot()
+// startRefresh starts a refresh of the token unless one is already in flight, and returns it.
+// otm.mu must be held.
+func (otm *OAuthTokenManager) startRefresh(ctx context.Context) *tokenRefresh {
+	if otm.inflight != nil {
+		return otm.inflight
+	}
+	call := &tokenRefresh{done: make(chan struct{})}
+	otm.inflight = call
+	go func() {
+		call.token, call.err = otm.fetchAndRefreshToken(ctx)
+		otm.mu.Lock()
+		otm.inflight = nil
+		switch {
+		case call.err == nil:
+			otm.failure = nil
+		case !isContextError(call.err):
+			otm.failedAt, otm.failure = timeNow(), call.err
+		}
+		otm.mu.Unlock()
+		close(call.done)
+	}()
+	return call
+}
+
+func (otm *OAuthTokenManager) fetchAndRefreshToken(ctx context.Context) (*OAuthToken, error) {
+	if otm.Store != nil {
+		if locker, ok := otm.Store.(TokenStoreLocker); ok {
+			unlock, err := locker.Lock(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to lock the token store: %w", err)
+			}
+			defer unlock()
+		}
+
+		// Another process may have refreshed the token already.
+		stored, err := otm.Store.Load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the token: %w", err)
+		}
+		if stored.valid() && !stored.expiresWithin(tokenRefreshAhead) {
+			otm.setToken(stored)
+			return stored, nil
+		}
+		if stored != nil && stored.RefreshToken != "" {
+			otm.mu.Lock()
+			if otm.Config != nil {
+				otm.Config.RefreshToken = stored.RefreshToken
+			}
+			otm.mu.Unlock()
+		}
+	}
+
+	tokenByte, err := otm.fetchTokenFromHubSpot(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -45,15 +168,39 @@ func (otm *OAuthTokenManager) RetrieveToken() (*OAuthToken, error) {
 		return nil, errors.New("missing authorization token")
 	}
 
-	return otm.refreshToken(tokenByte)
+	token, err := otm.refreshToken(tokenByte)
+	if err != nil {
+		return nil, err
+	}
+	if otm.Store != nil {
+		if err := otm.Store.Save(ctx, token); err != nil {
+			return nil, fmt.Errorf("failed to save the token: %w", err)
+		}
+	}
+	return token, nil
 }
 
-func (otm *OAuthTokenManager) fetchTokenFromHubSpot() (tokenByte []byte, err error) {
+func (otm *OAuthTokenManager) fetchTokenFromHubSpot(ctx context.Context) (tokenByte []byte, err error) {
+	otm.mu.Lock()
 	if err := otm.Config.valid(); err != nil {
+		otm.mu.Unlock()
+		return nil, err
+	}
+	form := otm.Config.convertToFormData()
+	otm.mu.Unlock()
+
+	return postOAuthForm(ctx, otm.HTTPClient, otm.oauthPath, form)
+}
+
+// postOAuthForm posts the form to the OAuth endpoint and returns the response body.
+func postOAuthForm(ctx context.Context, httpClient *http.Client, path string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, strings.NewReader(form.Encode()))
+	if err != nil {
 		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := otm.HTTPClient.PostForm(otm.oauthPath, otm.Config.convertToFormData())
+	res, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -80,9 +227,21 @@ func (otm *OAuthTokenManager) refreshToken(tokenByte []byte) (newToken *OAuthTok
 	if !newToken.valid() {
 		return nil, errors.New("invalid authorization token")
 	}
-	otm.Token = newToken
 
-	return otm.Token, nil
+	otm.setToken(newToken)
+
+	return newToken, nil
+}
+
+// setToken sets the token to use, along with its refresh token.
+func (otm *OAuthTokenManager) setToken(token *OAuthToken) {
+	otm.mu.Lock()
+	defer otm.mu.Unlock()
+	otm.Token = token
+	// HubSpot may rotate the refresh token, in which case the previous one stops working.
+	if otm.Config != nil {
+		otm.Config.RefreshToken = token.RefreshToken
+	}
 }
 
 type OAuthConfig struct {
@@ -145,6 +304,11 @@ func (ot *OAuthToken) valid() bool {
 	return ot != nil && ot.AccessToken != "" && ot.RefreshToken != "" && ot.expired()
 }
 
+// expiresWithin reports whether the token expires within d.
+func (ot *OAuthToken) expiresWithin(d time.Duration) bool {
+	return !ot.Expiry.IsZero() && !ot.Expiry.After(timeNow().Add(d))
+}
+
 func (ot *OAuthToken) expired() bool {
 	if ot.Expiry.IsZero() {
 		return false
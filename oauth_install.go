@@ -0,0 +1,245 @@
+package hubspot
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	oauthAuthorizeURL      = "https://app.hubspot.com/oauth/authorize"
+	oauthStateCookieName   = "hubspot_oauth_state"
+	oauthStateCookieMaxAge = 10 * time.Minute
+)
+
+// OAuthAppConfig is the configuration of a HubSpot OAuth app, used to install it on a portal.
+// Reference: https://developers.hubspot.com/docs/api/working-with-oauth
+type OAuthAppConfig struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURI is the URL of the callback handler, registered in the app settings.
+	RedirectURI string
+	// Scopes are the scopes the portal must grant to install the app.
+	Scopes []string
+	// OptionalScopes are granted only when the portal has access to them.
+	OptionalScopes []string
+	// HTTPClient is used to exchange the authorization code. http.DefaultClient is used when it is nil.
+	HTTPClient *http.Client
+	// BaseURL is the URL of the HubSpot API, as set by WithBaseURL. https://api.hubapi.com is used when it is nil.
+	BaseURL *url.URL
+}
+
+// OAuthInstall is the result of an app install.
+type OAuthInstall struct {
+	Token     *OAuthToken
+	PortalID  int64
+	HubDomain string
+	// User is the email address of the user who installed the app.
+	User   string
+	Scopes []string
+}
+
+// AuthorizeURL returns the URL of the HubSpot page where the user grants the scopes to the app.
+// state is sent back to the redirect URI to protect the flow against CSRF.
+func (ac *OAuthAppConfig) AuthorizeURL(state string) string {
+	q := url.Values{}
+	q.Set("client_id", ac.ClientID)
+	q.Set("redirect_uri", ac.RedirectURI)
+	q.Set("scope", strings.Join(ac.Scopes, " "))
+	if len(ac.OptionalScopes) > 0 {
+		q.Set("optional_scope", strings.Join(ac.OptionalScopes, " "))
+	}
+	if state != "" {
+		q.Set("state", state)
+	}
+	return oauthAuthorizeURL + "?" + q.Encode()
+}
+
+// RefreshConfig returns the OAuthConfig to use with SetOAuth for the portal which granted the refresh token.
+func (ac *OAuthAppConfig) RefreshConfig(refreshToken string) *OAuthConfig {
+	return &OAuthConfig{
+		GrantType:    GrantTypeRefreshToken,
+		ClientID:     ac.ClientID,
+		ClientSecret: ac.ClientSecret,
+		RefreshToken: refreshToken,
+	}
+}
+
+// ExchangeCode exchanges the authorization code received by the redirect URI for the tokens.
+func (ac *OAuthAppConfig) ExchangeCode(ctx context.Context, code string) (*OAuthToken, error) {
+	if code == "" {
+		return nil, errors.New("missing authorization code")
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", GrantTypeAuthorizationCode)
+	form.Set("client_id", ac.ClientID)
+	form.Set("client_secret", ac.ClientSecret)
+	form.Set("redirect_uri", ac.RedirectURI)
+	form.Set("code", code)
+
+	tokenByte, err := postOAuthForm(ctx, ac.httpClient(), fmt.Sprintf("%s/%s", ac.baseURL(), oauthTokenPath), form)
+	if err != nil {
+		return nil, err
+	}
+
+	token := new(OAuthToken)
+	if err := json.Unmarshal(tokenByte, token); err != nil {
+		return nil, err
+	}
+	token.setExpiry()
+	if !token.valid() {
+		return nil, errors.New("invalid authorization token")
+	}
+	return token, nil
+}
+
+// Install exchanges the authorization code and identifies the portal which installed the app.
+func (ac *OAuthAppConfig) Install(ctx context.Context, code string) (*OAuthInstall, error) {
+	token, err := ac.ExchangeCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s/%s", ac.baseURL(), oauthAccessTokenPath, url.PathEscape(token.AccessToken)), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := ac.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if isErrorStatusCode(res.StatusCode) {
+		return nil, fmt.Errorf("failed to get the access token information: %s", string(b))
+	}
+
+	var info AccessTokenInfo
+	if err := json.Unmarshal(b, &info); err != nil {
+		return nil, err
+	}
+	return &OAuthInstall{
+		Token:     token,
+		PortalID:  info.HubID,
+		HubDomain: info.HubDomain,
+		User:      info.User,
+		Scopes:    info.Scopes,
+	}, nil
+}
+
+func (ac *OAuthAppConfig) httpClient() *http.Client {
+	if ac.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return ac.HTTPClient
+}
+
+func (ac *OAuthAppConfig) baseURL() *url.URL {
+	if ac.BaseURL == nil {
+		return defaultBaseURL
+	}
+	return ac.BaseURL
+}
+
+// OAuthInstallHandler serves the install flow of an OAuth app.
+// StartHandler redirects the user to HubSpot, and CallbackHandler, served at the redirect URI,
+// completes the install and hands the result to OnInstall.
+//
+//	h := &hubspot.OAuthInstallHandler{
+//		Config: appConfig,
+//		OnInstall: func(w http.ResponseWriter, r *http.Request, install *hubspot.OAuthInstall) {
+//			// Save install.Token for install.PortalID, then redirect the user.
+//		},
+//	}
+//	http.Handle("/hubspot/install", h.StartHandler())
+//	http.Handle("/hubspot/callback", h.CallbackHandler())
+type OAuthInstallHandler struct {
+	Config *OAuthAppConfig
+	// OnInstall is called once the app has been installed. It writes the response to the user, and is required.
+	OnInstall func(w http.ResponseWriter, r *http.Request, install *OAuthInstall)
+	// OnError is called when the install fails. It responds with the status 400 or 502 when it is nil.
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// StartHandler returns the handler redirecting the user to the HubSpot authorize page.
+// The CSRF state is kept in a cookie checked by CallbackHandler.
+func (h *OAuthInstallHandler) StartHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state, err := newOAuthState()
+		if err != nil {
+			h.fail(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     oauthStateCookieName,
+			Value:    state,
+			Path:     "/",
+			MaxAge:   int(oauthStateCookieMaxAge.Seconds()),
+			HttpOnly: true,
+			Secure:   r.TLS != nil || strings.HasPrefix(h.Config.RedirectURI, "https://"),
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, h.Config.AuthorizeURL(state), http.StatusFound)
+	})
+}
+
+// CallbackHandler returns the handler served at the redirect URI, which completes the install.
+// It panics when OnInstall is nil.
+func (h *OAuthInstallHandler) CallbackHandler() http.Handler {
+	if h.OnInstall == nil {
+		panic("hubspot: OAuthInstallHandler.OnInstall is nil")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		cookie, err := r.Cookie(oauthStateCookieName)
+		if err != nil || q.Get("state") == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(q.Get("state"))) != 1 {
+			h.fail(w, r, http.StatusBadRequest, errors.New("invalid OAuth state"))
+			return
+		}
+		// The state can be used only once.
+		http.SetCookie(w, &http.Cookie{Name: oauthStateCookieName, Path: "/", MaxAge: -1})
+
+		if e := q.Get("error"); e != "" {
+			h.fail(w, r, http.StatusBadRequest, fmt.Errorf("authorization denied: %s: %s", e, q.Get("error_description")))
+			return
+		}
+
+		install, err := h.Config.Install(r.Context(), q.Get("code"))
+		if err != nil {
+			h.fail(w, r, http.StatusBadGateway, err)
+			return
+		}
+		h.OnInstall(w, r, install)
+	})
+}
+
+func (h *OAuthInstallHandler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.OnError != nil {
+		h.OnError(w, r, err)
+		return
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// newOAuthState returns a random state for the authorize URL.
+func newOAuthState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,264 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOAuthAppConfig_AuthorizeURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *hubspot.OAuthAppConfig
+		state  string
+		want   url.Values
+	}{
+		{
+			name: "Required scopes",
+			config: &hubspot.OAuthAppConfig{
+				ClientID:    "client_id",
+				RedirectURI: "https://example.com/callback",
+				Scopes:      []string{"crm.objects.contacts.read", "crm.objects.contacts.write"},
+			},
+			state: "state",
+			want: url.Values{
+				"client_id":    []string{"client_id"},
+				"redirect_uri": []string{"https://example.com/callback"},
+				"scope":        []string{"crm.objects.contacts.read crm.objects.contacts.write"},
+				"state":        []string{"state"},
+			},
+		},
+		{
+			name: "Optional scopes",
+			config: &hubspot.OAuthAppConfig{
+				ClientID:       "client_id",
+				RedirectURI:    "https://example.com/callback",
+				Scopes:         []string{"oauth"},
+				OptionalScopes: []string{"tickets", "e-commerce"},
+			},
+			want: url.Values{
+				"client_id":      []string{"client_id"},
+				"redirect_uri":   []string{"https://example.com/callback"},
+				"scope":          []string{"oauth"},
+				"optional_scope": []string{"tickets e-commerce"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.config.AuthorizeURL(tt.state))
+			if err != nil {
+				t.Fatalf("AuthorizeURL() returned an invalid URL: %s", err)
+			}
+			if got := u.Scheme + "://" + u.Host + u.Path; got != "https://app.hubspot.com/oauth/authorize" {
+				t.Errorf("AuthorizeURL() endpoint mismatch: got %s", got)
+			}
+			if diff := cmp.Diff(tt.want, u.Query()); diff != "" {
+				t.Errorf("AuthorizeURL() query mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestOAuthAppConfig_ExchangeCode(t *testing.T) {
+	f := hubspot.MockTimeNow()
+	defer f()
+
+	tests := []struct {
+		name     string
+		code     string
+		status   int
+		body     string
+		want     *hubspot.OAuthToken
+		wantForm url.Values
+		wantErr  error
+	}{
+		{
+			name:   "Success",
+			code:   "auth_code",
+			status: http.StatusOK,
+			body:   `{"access_token": "test_access_token","refresh_token": "test_refresh_token","expires_in": 1800}`,
+			want: &hubspot.OAuthToken{
+				AccessToken:  "test_access_token",
+				RefreshToken: "test_refresh_token",
+				ExpiresIn:    1800,
+				Expiry:       time.Date(2020, 12, 31, 12, 20, 0, 0, time.UTC),
+			},
+			wantForm: url.Values{
+				"grant_type":    []string{"authorization_code"},
+				"client_id":     []string{"client_id"},
+				"client_secret": []string{"client_secret"},
+				"redirect_uri":  []string{"https://example.com/callback"},
+				"code":          []string{"auth_code"},
+			},
+		},
+		{
+			name:    "Missing code",
+			wantErr: errors.New("missing authorization code"),
+		},
+		{
+			name:    "Error response",
+			code:    "expired_code",
+			status:  http.StatusBadRequest,
+			body:    `{"status":"BAD_AUTH_CODE","message":"missing or unknown auth code"}`,
+			wantErr: errors.New(`failed to authorize: {"status":"BAD_AUTH_CODE","message":"missing or unknown auth code"}`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotForm url.Values
+			config := &hubspot.OAuthAppConfig{
+				ClientID:     "client_id",
+				ClientSecret: "client_secret",
+				RedirectURI:  "https://example.com/callback",
+				HTTPClient: &http.Client{
+					Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+						if err := req.ParseForm(); err != nil {
+							return nil, err
+						}
+						gotForm = req.PostForm
+						return &http.Response{
+							StatusCode: tt.status,
+							Body:       ioutil.NopCloser(bytes.NewBufferString(tt.body)),
+						}, nil
+					}),
+				},
+			}
+
+			got, err := config.ExchangeCode(context.Background(), tt.code)
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("ExchangeCode() error mismatch: want %s got %s", tt.wantErr, err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ExchangeCode() response mismatch (-want +got):%s", diff)
+			}
+			if tt.wantForm == nil {
+				return
+			}
+			if diff := cmp.Diff(tt.wantForm, gotForm); diff != "" {
+				t.Errorf("ExchangeCode() form mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestOAuthInstallHandler(t *testing.T) {
+	f := hubspot.MockTimeNow()
+	defer f()
+
+	var gotInstall *hubspot.OAuthInstall
+	h := &hubspot.OAuthInstallHandler{
+		Config: &hubspot.OAuthAppConfig{
+			ClientID:     "client_id",
+			ClientSecret: "client_secret",
+			RedirectURI:  "https://example.com/callback",
+			Scopes:       []string{"crm.objects.contacts.read"},
+			BaseURL:      &url.URL{Scheme: "https", Host: "api.hubapi.example.com"},
+			HTTPClient: &http.Client{
+				Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+					if req.URL.Host != "api.hubapi.example.com" {
+						t.Errorf("host mismatch: want api.hubapi.example.com got %s", req.URL.Host)
+					}
+					body := `{"access_token": "test_access_token","refresh_token": "test_refresh_token","expires_in": 1800}`
+					if req.Method == http.MethodGet {
+						if req.URL.Path != "/oauth/v1/access-tokens/test_access_token" {
+							t.Errorf("token information path mismatch: got %s", req.URL.Path)
+						}
+						body = `{"token":"test_access_token","user":"user@example.com","hub_domain":"example.com","scopes":["oauth","crm.objects.contacts.read"],"hub_id":62515,"app_id":456,"expires_in":1754,"user_id":123,"token_type":"access"}`
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+					}, nil
+				}),
+			},
+		},
+		OnInstall: func(w http.ResponseWriter, r *http.Request, install *hubspot.OAuthInstall) {
+			gotInstall = install
+			w.WriteHeader(http.StatusNoContent)
+		},
+	}
+
+	// Start the install.
+	rec := httptest.NewRecorder()
+	h.StartHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/install", nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("StartHandler() status mismatch: want %d got %d", http.StatusFound, rec.Code)
+	}
+	location, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := location.Query().Get("state")
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Value != state || state == "" {
+		t.Fatalf("StartHandler() must set the state cookie: state %q cookies %v", state, cookies)
+	}
+
+	t.Run("Invalid state", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/callback?code=auth_code&state=forged", nil)
+		req.AddCookie(cookies[0])
+		rec := httptest.NewRecorder()
+		h.CallbackHandler().ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("CallbackHandler() status mismatch: want %d got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("Access denied", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/callback?error=access_denied&state="+state, nil)
+		req.AddCookie(cookies[0])
+		rec := httptest.NewRecorder()
+		h.CallbackHandler().ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("CallbackHandler() status mismatch: want %d got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/callback?code=auth_code&state="+state, nil)
+		req.AddCookie(cookies[0])
+		rec := httptest.NewRecorder()
+		h.CallbackHandler().ServeHTTP(rec, req)
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("CallbackHandler() status mismatch: want %d got %d", http.StatusNoContent, rec.Code)
+		}
+
+		want := &hubspot.OAuthInstall{
+			Token: &hubspot.OAuthToken{
+				AccessToken:  "test_access_token",
+				RefreshToken: "test_refresh_token",
+				ExpiresIn:    1800,
+				Expiry:       time.Date(2020, 12, 31, 12, 20, 0, 0, time.UTC),
+			},
+			PortalID:  62515,
+			HubDomain: "example.com",
+			User:      "user@example.com",
+			Scopes:    []string{"oauth", "crm.objects.contacts.read"},
+		}
+		if diff := cmp.Diff(want, gotInstall); diff != "" {
+			t.Errorf("OnInstall() install mismatch (-want +got):%s", diff)
+		}
+	})
+}
+
+func TestOAuthInstallHandler_CallbackHandlerWithoutOnInstall(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("CallbackHandler() must panic when OnInstall is nil")
+		}
+	}()
+	h := &hubspot.OAuthInstallHandler{Config: &hubspot.OAuthAppConfig{}}
+	h.CallbackHandler()
+}
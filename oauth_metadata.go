@@ -0,0 +1,129 @@
+package hubspot
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+const (
+	oauthAccessTokenPath  = "oauth/v1/access-tokens"
+	oauthRefreshTokenPath = "oauth/v1/refresh-tokens"
+)
+
+// OAuthService is an interface of the OAuth metadata endpoints of the HubSpot API.
+// It tells which portal, user and scopes a token belongs to, and revokes refresh tokens when an app is uninstalled.
+// Reference: https://developers.hubspot.com/docs/api/oauth/tokens
+type OAuthService interface {
+	GetAccessToken(token string) (*AccessTokenInfo, error)
+	GetAccessTokenWithContext(ctx context.Context, token string) (*AccessTokenInfo, error)
+	GetRefreshToken(token string) (*RefreshTokenInfo, error)
+	GetRefreshTokenWithContext(ctx context.Context, token string) (*RefreshTokenInfo, error)
+	RevokeRefreshToken(token string) error
+	RevokeRefreshTokenWithContext(ctx context.Context, token string) error
+}
+
+// OAuthServiceOp handles communication with the OAuth metadata related methods of the HubSpot API.
+type OAuthServiceOp struct {
+	client *Client
+}
+
+var _ OAuthService = (*OAuthServiceOp)(nil)
+
+func newOAuth(c *Client) OAuthService {
+	return &OAuthServiceOp{client: c}
+}
+
+// AccessTokenInfo is the metadata of an OAuth access token.
+type AccessTokenInfo struct {
+	Token     string   `json:"token"`
+	User      string   `json:"user"`
+	UserID    int64    `json:"user_id"`
+	HubDomain string   `json:"hub_domain"`
+	HubID     int64    `json:"hub_id"`
+	AppID     int64    `json:"app_id"`
+	Scopes    []string `json:"scopes"`
+	TokenType string   `json:"token_type"`
+	ExpiresIn int      `json:"expires_in"`
+	// Expiry is the time when the token expires, computed from ExpiresIn.
+	Expiry time.Time `json:"-"`
+}
+
+// RefreshTokenInfo is the metadata of an OAuth refresh token.
+type RefreshTokenInfo struct {
+	Token     string   `json:"token"`
+	User      string   `json:"user"`
+	UserID    int64    `json:"user_id"`
+	HubDomain string   `json:"hub_domain"`
+	HubID     int64    `json:"hub_id"`
+	ClientID  string   `json:"client_id"`
+	Scopes    []string `json:"scopes"`
+	TokenType string   `json:"token_type"`
+}
+
+// MissingScopes returns the scopes among the required ones which have not been granted to the token.
+func (i *AccessTokenInfo) MissingScopes(required ...string) []string {
+	return missingScopes(i.Scopes, required)
+}
+
+// GetAccessToken gets the metadata of an access token, such as the portal it has been issued for.
+func (s *OAuthServiceOp) GetAccessToken(token string) (*AccessTokenInfo, error) {
+	return s.GetAccessTokenWithContext(context.Background(), token)
+}
+
+// GetAccessTokenWithContext gets the metadata of an access token with the given context.
+func (s *OAuthServiceOp) GetAccessTokenWithContext(ctx context.Context, token string) (*AccessTokenInfo, error) {
+	ctx = withOperation(ctx, "OAuth.GetAccessToken", "")
+	info := &AccessTokenInfo{}
+	if err := s.client.GetWithContext(ctx, oauthAccessTokenPath+"/"+url.PathEscape(token), info, nil); err != nil {
+		return nil, err
+	}
+	info.setExpiry()
+	return info, nil
+}
+
+// GetRefreshToken gets the metadata of a refresh token.
+func (s *OAuthServiceOp) GetRefreshToken(token string) (*RefreshTokenInfo, error) {
+	return s.GetRefreshTokenWithContext(context.Background(), token)
+}
+
+// GetRefreshTokenWithContext gets the metadata of a refresh token with the given context.
+func (s *OAuthServiceOp) GetRefreshTokenWithContext(ctx context.Context, token string) (*RefreshTokenInfo, error) {
+	ctx = withOperation(ctx, "OAuth.GetRefreshToken", "")
+	info := &RefreshTokenInfo{}
+	if err := s.client.GetWithContext(ctx, oauthRefreshTokenPath+"/"+url.PathEscape(token), info, nil); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// RevokeRefreshToken revokes a refresh token, e.g. when the app is uninstalled from a portal.
+// The access tokens issued with it remain valid until they expire.
+func (s *OAuthServiceOp) RevokeRefreshToken(token string) error {
+	return s.RevokeRefreshTokenWithContext(context.Background(), token)
+}
+
+// RevokeRefreshTokenWithContext revokes a refresh token with the given context.
+func (s *OAuthServiceOp) RevokeRefreshTokenWithContext(ctx context.Context, token string) error {
+	ctx = withOperation(ctx, "OAuth.RevokeRefreshToken", "")
+	return s.client.DeleteWithContext(ctx, oauthRefreshTokenPath+"/"+url.PathEscape(token), nil)
+}
+
+func (i *AccessTokenInfo) setExpiry() {
+	i.Expiry = timeNow().Add(time.Duration(i.ExpiresIn) * time.Second)
+}
+
+// missingScopes returns the required scopes which are not granted.
+func missingScopes(granted, required []string) []string {
+	grantedSet := make(map[string]bool, len(granted))
+	for _, s := range granted {
+		grantedSet[s] = true
+	}
+	var missing []string
+	for _, s := range required {
+		if !grantedSet[s] {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
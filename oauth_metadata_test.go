@@ -0,0 +1,139 @@
+package hubspot_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOAuthServiceOp_GetAccessToken(t *testing.T) {
+	f := hubspot.MockTimeNow()
+	defer f()
+
+	tests := []struct {
+		name    string
+		client  *hubspot.Client
+		want    *hubspot.AccessTokenInfo
+		wantErr error
+	}{
+		{
+			name: "Successfully get an access token",
+			client: newOAuthMetadataClient(&hubspot.MockConfig{
+				Status: http.StatusOK,
+				Body:   []byte(`{"token":"access_token","user":"user@example.com","hub_domain":"example.com","scopes":["oauth","crm.objects.contacts.read"],"hub_id":62515,"app_id":456,"expires_in":1754,"user_id":123,"token_type":"access"}`),
+			}),
+			want: &hubspot.AccessTokenInfo{
+				Token:     "access_token",
+				User:      "user@example.com",
+				UserID:    123,
+				HubDomain: "example.com",
+				HubID:     62515,
+				AppID:     456,
+				Scopes:    []string{"oauth", "crm.objects.contacts.read"},
+				TokenType: "access",
+				ExpiresIn: 1754,
+				Expiry:    time.Date(2020, 12, 31, 12, 29, 14, 0, time.UTC),
+			},
+		},
+		{
+			name: "Received unknown token",
+			client: newOAuthMetadataClient(&hubspot.MockConfig{
+				Status: http.StatusNotFound,
+				Body:   []byte(`{"status":"error","message":"Token not found"}`),
+			}),
+			wantErr: &hubspot.APIError{
+				HTTPStatusCode: http.StatusNotFound,
+				Status:         "error",
+				Message:        "Token not found",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.client.OAuth.GetAccessToken("access_token")
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("GetAccessToken() error mismatch: want %s got %s", tt.wantErr, err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetAccessToken() response mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestOAuthServiceOp_GetRefreshToken(t *testing.T) {
+	c := newOAuthMetadataClient(&hubspot.MockConfig{
+		Status: http.StatusOK,
+		Body:   []byte(`{"token":"refresh_token","user":"user@example.com","hub_domain":"example.com","scopes":["oauth"],"hub_id":62515,"client_id":"client_id","user_id":123,"token_type":"refresh"}`),
+	})
+
+	got, err := c.OAuth.GetRefreshToken("refresh_token")
+	if err != nil {
+		t.Fatalf("GetRefreshToken() error: %s", err)
+	}
+	want := &hubspot.RefreshTokenInfo{
+		Token:     "refresh_token",
+		User:      "user@example.com",
+		UserID:    123,
+		HubDomain: "example.com",
+		HubID:     62515,
+		ClientID:  "client_id",
+		Scopes:    []string{"oauth"},
+		TokenType: "refresh",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetRefreshToken() response mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestOAuthServiceOp_RevokeRefreshToken(t *testing.T) {
+	var (
+		gotReq   *http.Request
+		gotEvent *hubspot.LogEvent
+	)
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"),
+		hubspot.WithHTTPClient(&http.Client{
+			Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+				gotReq = req
+				return hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: http.StatusNoContent}).Transport.RoundTrip(req)
+			}),
+		}),
+		hubspot.WithLogger(hubspot.LoggerFunc(func(_ context.Context, e *hubspot.LogEvent) {
+			gotEvent = e
+		})),
+	)
+
+	if err := c.OAuth.RevokeRefreshToken("secret-refresh-token"); err != nil {
+		t.Fatalf("RevokeRefreshToken() error: %s", err)
+	}
+	if gotReq.Method != http.MethodDelete || gotReq.URL.Path != "/oauth/v1/refresh-tokens/secret-refresh-token" {
+		t.Errorf("request mismatch: got %s %s", gotReq.Method, gotReq.URL.Path)
+	}
+
+	// The token must not be written in the logs.
+	if want := "oauth/v1/refresh-tokens/{token}"; gotEvent.PathTemplate != want {
+		t.Errorf("LogEvent.PathTemplate mismatch: want %s got %s", want, gotEvent.PathTemplate)
+	}
+	if want := "https://api.hubapi.com/oauth/v1/refresh-tokens/REDACTED"; gotEvent.URL != want {
+		t.Errorf("LogEvent.URL mismatch: want %s got %s", want, gotEvent.URL)
+	}
+}
+
+func TestAccessTokenInfo_MissingScopes(t *testing.T) {
+	info := &hubspot.AccessTokenInfo{Scopes: []string{"oauth", "crm.objects.contacts.read"}}
+	got := info.MissingScopes("crm.objects.contacts.read", "crm.objects.contacts.write", "tickets")
+	if diff := cmp.Diff([]string{"crm.objects.contacts.write", "tickets"}, got); diff != "" {
+		t.Errorf("MissingScopes() result mismatch (-want +got):%s", diff)
+	}
+}
+
+func newOAuthMetadataClient(conf *hubspot.MockConfig) *hubspot.Client {
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(hubspot.NewMockHTTPClient(conf)))
+	return c
+}
@@ -1,10 +1,16 @@
 package hubspot_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -129,6 +135,103 @@ func TestOAuthTokenManager_RetrieveToken(t *testing.T) {
 	}
 }
 
+func TestOAuthTokenManager_RetrieveToken_Concurrent(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	otm := &hubspot.OAuthTokenManager{
+		HTTPClient: &http.Client{
+			Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"access_token": "new_access_token","refresh_token": "rotated_refresh_token","expires_in": 21600}`)),
+				}, nil
+			}),
+		},
+		Config: &hubspot.OAuthConfig{
+			GrantType:    hubspot.GrantTypeRefreshToken,
+			ClientID:     "client_id",
+			ClientSecret: "client_secret",
+			RefreshToken: "refresh_token",
+		},
+	}
+
+	const goroutines = 10
+	var wg sync.WaitGroup
+	tokens := make([]string, goroutines)
+	errs := make([]error, goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token, err := otm.RetrieveToken()
+			errs[i] = err
+			if err == nil {
+				tokens[i] = token.AccessToken
+			}
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := 0; i < goroutines; i++ {
+		if errs[i] != nil {
+			t.Fatalf("RetrieveToken() error: %s", errs[i])
+		}
+		if tokens[i] != "new_access_token" {
+			t.Errorf("RetrieveToken() access token mismatch: want new_access_token got %s", tokens[i])
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("token requests mismatch: want 1 got %d", got)
+	}
+	if otm.Config.RefreshToken != "rotated_refresh_token" {
+		t.Errorf("Config.RefreshToken mismatch: want rotated_refresh_token got %s", otm.Config.RefreshToken)
+	}
+}
+
+func TestOAuthTokenManager_RetrieveToken_RefreshAhead(t *testing.T) {
+	otm := &hubspot.OAuthTokenManager{
+		HTTPClient: hubspot.NewMockHTTPClient(&hubspot.MockConfig{
+			Status: http.StatusOK,
+			Body:   []byte(`{"access_token": "new_access_token","refresh_token": "refresh_token","expires_in": 21600}`),
+		}),
+		Config: &hubspot.OAuthConfig{
+			GrantType:    hubspot.GrantTypeRefreshToken,
+			ClientID:     "client_id",
+			ClientSecret: "client_secret",
+			RefreshToken: "refresh_token",
+		},
+		Token: &hubspot.OAuthToken{
+			AccessToken:  "old_access_token",
+			RefreshToken: "refresh_token",
+			Expiry:       time.Now().Add(time.Minute),
+		},
+	}
+
+	// The token about to expire is still returned while a new one is retrieved in the background.
+	token, err := otm.RetrieveToken()
+	if err != nil {
+		t.Fatalf("RetrieveToken() error: %s", err)
+	}
+	if token.AccessToken != "old_access_token" {
+		t.Errorf("RetrieveToken() access token mismatch: want old_access_token got %s", token.AccessToken)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for token.AccessToken != "new_access_token" {
+		if time.Now().After(deadline) {
+			t.Fatal("the token has not been refreshed in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+		if token, err = otm.RetrieveToken(); err != nil {
+			t.Fatalf("RetrieveToken() error: %s", err)
+		}
+	}
+}
+
 func TestOAuthTokenManager_fetchTokenFromHubSpot(t *testing.T) {
 	type fields struct {
 		HTTPClient *http.Client
@@ -209,7 +312,7 @@ func TestOAuthTokenManager_fetchTokenFromHubSpot(t *testing.T) {
 				Config:     tt.fields.Config,
 				Token:      tt.fields.Token,
 			}
-			got, err := hubspot.ExportFetchTokenFromHubSpot(otm)
+			got, err := hubspot.ExportFetchTokenFromHubSpot(otm, context.Background())
 			if !reflect.DeepEqual(tt.wantErr, err) {
 				t.Errorf("fetchTokenFromHubSpot() error mismatch: want %s got %s", tt.wantErr, err)
 				return
@@ -635,3 +738,82 @@ func TestOAuthToken_expired(t *testing.T) {
 		})
 	}
 }
+
+func TestOAuthTokenManager_RetrieveToken_RefreshBackoff(t *testing.T) {
+	var mu sync.Mutex
+	now := time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC)
+	defer hubspot.MockTimeNowFunc(func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	})()
+	advance := func(d time.Duration) {
+		mu.Lock()
+		now = now.Add(d)
+		mu.Unlock()
+	}
+
+	var calls int32
+	otm := &hubspot.OAuthTokenManager{
+		HTTPClient: &http.Client{
+			Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&calls, 1)
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"status":"BAD_REFRESH_TOKEN","message":"missing or unknown refresh token","error":"invalid_grant"}`)),
+				}, nil
+			}),
+		},
+		Config: &hubspot.OAuthConfig{
+			GrantType:    hubspot.GrantTypeRefreshToken,
+			ClientID:     "client_id",
+			ClientSecret: "client_secret",
+			RefreshToken: "refresh_token",
+		},
+		Token: &hubspot.OAuthToken{
+			AccessToken:  "old_access_token",
+			RefreshToken: "refresh_token",
+			Expiry:       now.Add(10 * time.Second),
+		},
+	}
+
+	// The background refresh fails once, and is not sent again by the next calls.
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&calls) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the token has not been refreshed in the background")
+		}
+		if _, err := otm.RetrieveToken(); err != nil {
+			t.Fatalf("RetrieveToken() error: %s", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		if _, err := otm.RetrieveToken(); err != nil {
+			t.Fatalf("RetrieveToken() error: %s", err)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("token requests mismatch: want 1 got %d", got)
+	}
+
+	// Once the token has expired, the failure is returned without sending another refresh.
+	advance(20 * time.Second)
+	_, err := otm.RetrieveToken()
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("RetrieveToken() error mismatch: want the refresh failure got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("token requests mismatch: want 1 got %d", got)
+	}
+
+	// After the backoff, the token is refreshed again.
+	advance(time.Minute)
+	if _, err := otm.RetrieveToken(); err == nil {
+		t.Error("RetrieveToken() error mismatch: want an error got nil")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("token requests mismatch: want 2 got %d", got)
+	}
+}
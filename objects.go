@@ -0,0 +1,212 @@
+package hubspot
+
+import (
+	"context"
+	"net/url"
+)
+
+// ObjectService is an interface of the CRM objects endpoints of the HubSpot API, for any object type
+// including the custom objects. Get it with CRM.Objects.
+// The properties are bound to the structure given as an argument, or else to a map[string]interface{}.
+// Reference: https://developers.hubspot.com/docs/api/crm/understanding-the-crm
+type ObjectService interface {
+	Get(objectID string, properties interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	GetWithContext(ctx context.Context, objectID string, properties interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	List(option *ObjectListOption) (*ObjectList, error)
+	ListWithContext(ctx context.Context, option *ObjectListOption) (*ObjectList, error)
+	Create(properties interface{}) (*ResponseResource, error)
+	CreateWithContext(ctx context.Context, properties interface{}) (*ResponseResource, error)
+	Update(objectID string, properties interface{}) (*ResponseResource, error)
+	UpdateWithContext(ctx context.Context, objectID string, properties interface{}) (*ResponseResource, error)
+	Archive(objectID string) error
+	ArchiveWithContext(ctx context.Context, objectID string) error
+	Search(req *ObjectSearchRequest) (*ObjectList, error)
+	SearchWithContext(ctx context.Context, req *ObjectSearchRequest) (*ObjectList, error)
+	AssociateAnotherObj(objectID string, conf *AssociationConfig) (*ResponseResource, error)
+	AssociateAnotherObjWithContext(ctx context.Context, objectID string, conf *AssociationConfig) (*ResponseResource, error)
+	BatchRead(objectIDs []string, option *BatchReadOption) (*BatchResponse, error)
+	BatchReadWithContext(ctx context.Context, objectIDs []string, option *BatchReadOption) (*BatchResponse, error)
+	BatchCreate(properties []interface{}) (*BatchResponse, error)
+	BatchCreateWithContext(ctx context.Context, properties []interface{}) (*BatchResponse, error)
+	BatchUpdate(inputs []*BatchUpdateInput) (*BatchResponse, error)
+	BatchUpdateWithContext(ctx context.Context, inputs []*BatchUpdateInput) (*BatchResponse, error)
+	BatchUpsert(inputs []*BatchUpsertInput) (*BatchResponse, error)
+	BatchUpsertWithContext(ctx context.Context, inputs []*BatchUpsertInput) (*BatchResponse, error)
+	BatchArchive(objectIDs []string) error
+	BatchArchiveWithContext(ctx context.Context, objectIDs []string) error
+}
+
+// ObjectServiceOp handles communication with the CRM objects related methods of the HubSpot API.
+type ObjectServiceOp struct {
+	objectType ObjectType
+	objectPath string
+	client     *Client
+}
+
+var _ ObjectService = (*ObjectServiceOp)(nil)
+
+// standardObjectTypes maps the singular names of the standard objects to the names used in the paths.
+var standardObjectTypes = map[ObjectType]ObjectType{
+	"contact":   ObjectTypeContact,
+	"company":   "companies",
+	"deal":      ObjectTypeDeal,
+	"ticket":    ObjectTypeTicket,
+	"note":      ObjectTypeNote,
+	"product":   ObjectTypeProduct,
+	"line_item": ObjectTypeLineItem,
+}
+
+// Objects returns the service of an object type. The object type is the name of a standard object, e.g. ObjectTypeContact,
+// an object type ID, e.g. 2-12345 for a custom object, or the fully qualified name of a custom object, e.g. p12345_cars.
+func (c *CRM) Objects(objectType ObjectType) ObjectService {
+	if t, ok := standardObjectTypes[objectType]; ok {
+		objectType = t
+	}
+	return &ObjectServiceOp{
+		objectType: objectType,
+		objectPath: c.crmPath + "/" + objectsBasePath + "/" + url.PathEscape(string(objectType)),
+		client:     c.client,
+	}
+}
+
+// ObjectListOption is a set of options to list objects.
+type ObjectListOption struct {
+	// Properties are the properties to be returned. Only a few default properties are returned when it is empty.
+	Properties   []string `url:"properties,comma,omitempty"`
+	Associations []string `url:"associations,comma,omitempty"`
+	Archived     bool     `url:"archived,omitempty"`
+	// Limit is the maximum number of results per page, 100 at most.
+	Limit int `url:"limit,omitempty"`
+	// After is the cursor of the page, Paging.Next.After of the previous page.
+	After string `url:"after,omitempty"`
+}
+
+// ObjectSearchRequest represents the request body for searching objects.
+type ObjectSearchRequest struct {
+	SearchOptions
+}
+
+// ObjectList is a page of objects returned by List and Search.
+type ObjectList struct {
+	// Total is the total number of objects matching a search.
+	Total   int64               `json:"total,omitempty"`
+	Results []*ResponseResource `json:"results"`
+	Paging  *Paging             `json:"paging,omitempty"`
+}
+
+// Paging tells the cursor of the next page, when there is one.
+type Paging struct {
+	Next *PagingData `json:"next,omitempty"`
+}
+
+type PagingData struct {
+	After string `json:"after"`
+	Link  string `json:"link,omitempty"`
+}
+
+// Get gets an object.
+// The properties to get must be specified in option.Properties or option.CustomProperties, or else only a few default properties are returned.
+func (s *ObjectServiceOp) Get(objectID string, properties interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	return s.GetWithContext(context.Background(), objectID, properties, option)
+}
+
+// GetWithContext gets an object with the given context.
+func (s *ObjectServiceOp) GetWithContext(ctx context.Context, objectID string, properties interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Objects.Get", string(s.objectType))
+	resource := &ResponseResource{Properties: properties}
+	if option != nil {
+		option = option.setupProperties(append([]string(nil), option.Properties...))
+	}
+	if err := s.client.GetWithContext(ctx, s.objectPath+"/"+objectID, resource, option); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// List lists a page of objects.
+func (s *ObjectServiceOp) List(option *ObjectListOption) (*ObjectList, error) {
+	return s.ListWithContext(context.Background(), option)
+}
+
+// ListWithContext lists a page of objects with the given context.
+func (s *ObjectServiceOp) ListWithContext(ctx context.Context, option *ObjectListOption) (*ObjectList, error) {
+	ctx = withOperation(ctx, "CRM.Objects.List", string(s.objectType))
+	resource := &ObjectList{}
+	if err := s.client.GetWithContext(ctx, s.objectPath, resource, option); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Create creates a new object.
+func (s *ObjectServiceOp) Create(properties interface{}) (*ResponseResource, error) {
+	return s.CreateWithContext(context.Background(), properties)
+}
+
+// CreateWithContext creates a new object with the given context.
+func (s *ObjectServiceOp) CreateWithContext(ctx context.Context, properties interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Objects.Create", string(s.objectType))
+	req := &RequestPayload{Properties: properties}
+	resource := &ResponseResource{Properties: properties}
+	if err := s.client.PostWithContext(ctx, s.objectPath, req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Update updates an object.
+func (s *ObjectServiceOp) Update(objectID string, properties interface{}) (*ResponseResource, error) {
+	return s.UpdateWithContext(context.Background(), objectID, properties)
+}
+
+// UpdateWithContext updates an object with the given context.
+func (s *ObjectServiceOp) UpdateWithContext(ctx context.Context, objectID string, properties interface{}) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Objects.Update", string(s.objectType))
+	req := &RequestPayload{Properties: properties}
+	resource := &ResponseResource{Properties: properties}
+	if err := s.client.PatchWithContext(ctx, s.objectPath+"/"+objectID, req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Archive archives an object.
+func (s *ObjectServiceOp) Archive(objectID string) error {
+	return s.ArchiveWithContext(context.Background(), objectID)
+}
+
+// ArchiveWithContext archives an object with the given context.
+func (s *ObjectServiceOp) ArchiveWithContext(ctx context.Context, objectID string) error {
+	ctx = withOperation(ctx, "CRM.Objects.Archive", string(s.objectType))
+	return s.client.DeleteWithContext(ctx, s.objectPath+"/"+objectID, nil)
+}
+
+// Search searches for objects by any given property filters.
+func (s *ObjectServiceOp) Search(req *ObjectSearchRequest) (*ObjectList, error) {
+	return s.SearchWithContext(context.Background(), req)
+}
+
+// SearchWithContext searches for objects with the given context.
+func (s *ObjectServiceOp) SearchWithContext(ctx context.Context, req *ObjectSearchRequest) (*ObjectList, error) {
+	ctx = withOperation(ctx, "CRM.Objects.Search", string(s.objectType))
+	resource := &ObjectList{}
+	if err := s.client.PostWithContext(ctx, s.objectPath+"/search", req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// AssociateAnotherObj associates an object with another HubSpot object.
+func (s *ObjectServiceOp) AssociateAnotherObj(objectID string, conf *AssociationConfig) (*ResponseResource, error) {
+	return s.AssociateAnotherObjWithContext(context.Background(), objectID, conf)
+}
+
+// AssociateAnotherObjWithContext associates an object with another HubSpot object using the given context.
+func (s *ObjectServiceOp) AssociateAnotherObjWithContext(ctx context.Context, objectID string, conf *AssociationConfig) (*ResponseResource, error) {
+	ctx = withOperation(ctx, "CRM.Objects.AssociateAnotherObj", string(s.objectType))
+	resource := &ResponseResource{}
+	if err := s.client.PutWithContext(ctx, s.objectPath+"/"+objectID+"/"+conf.makeAssociationPath(), nil, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
@@ -0,0 +1,222 @@
+package hubspot_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+type objectRequest struct {
+	Method string
+	URL    string
+	Body   string
+}
+
+func newObjectsClient(t *testing.T, status int, response string, got *objectRequest) *hubspot.Client {
+	t.Helper()
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(&http.Client{
+		Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+			got.Method, got.URL = req.Method, req.URL.RequestURI()
+			if req.Body != nil {
+				b, _ := ioutil.ReadAll(req.Body)
+				got.Body = string(b)
+			}
+			return hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: status, Body: []byte(response)}).Transport.RoundTrip(req)
+		}),
+	}))
+	return c
+}
+
+func TestCRM_Objects(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectType hubspot.ObjectType
+		wantPath   string
+	}{
+		{name: "Standard object", objectType: hubspot.ObjectTypeContact, wantPath: "/crm/v3/objects/contacts/1"},
+		{name: "Company constant", objectType: hubspot.ObjectTypeCompany, wantPath: "/crm/v3/objects/companies/1"},
+		{name: "Singular name", objectType: "ticket", wantPath: "/crm/v3/objects/tickets/1"},
+		{name: "Object type ID", objectType: "2-12345", wantPath: "/crm/v3/objects/2-12345/1"},
+		{name: "Fully qualified name", objectType: "p12345_cars", wantPath: "/crm/v3/objects/p12345_cars/1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got objectRequest
+			c := newObjectsClient(t, http.StatusOK, `{"id":"1","properties":{"name":"car"}}`, &got)
+
+			res, err := c.CRM.Objects(tt.objectType).Get("1", nil, nil)
+			if err != nil {
+				t.Fatalf("Get() error: %s", err)
+			}
+			if got.URL != tt.wantPath {
+				t.Errorf("path mismatch: want %s got %s", tt.wantPath, got.URL)
+			}
+			want := &hubspot.ResponseResource{ID: "1", Properties: map[string]interface{}{"name": "car"}}
+			if diff := cmp.Diff(want, res); diff != "" {
+				t.Errorf("Get() response mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectServiceOp(t *testing.T) {
+	type car struct {
+		Name  *hubspot.HsStr `json:"name,omitempty"`
+		Model *hubspot.HsStr `json:"model,omitempty"`
+	}
+
+	tests := []struct {
+		name     string
+		call     func(s hubspot.ObjectService) (interface{}, error)
+		status   int
+		response string
+		wantReq  objectRequest
+		want     interface{}
+	}{
+		{
+			name: "Get with properties",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return s.Get("1", &car{}, &hubspot.RequestQueryOption{Properties: []string{"name"}, CustomProperties: []string{"model"}})
+			},
+			status:   http.StatusOK,
+			response: `{"id":"1","properties":{"name":"car","model":"x"}}`,
+			wantReq:  objectRequest{Method: http.MethodGet, URL: "/crm/v3/objects/p12345_cars/1?properties=name%2Cmodel"},
+			want:     &hubspot.ResponseResource{ID: "1", Properties: &car{Name: hubspot.NewString("car"), Model: hubspot.NewString("x")}},
+		},
+		{
+			name: "List",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return s.List(&hubspot.ObjectListOption{Properties: []string{"name"}, Limit: 1, After: "1"})
+			},
+			status:   http.StatusOK,
+			response: `{"results":[{"id":"2","properties":{"name":"car"}}],"paging":{"next":{"after":"3","link":"https://api.hubapi.com/crm/v3/objects/p12345_cars?after=3"}}}`,
+			wantReq:  objectRequest{Method: http.MethodGet, URL: "/crm/v3/objects/p12345_cars?after=1&limit=1&properties=name"},
+			want: &hubspot.ObjectList{
+				Results: []*hubspot.ResponseResource{{ID: "2", Properties: map[string]interface{}{"name": "car"}}},
+				Paging:  &hubspot.Paging{Next: &hubspot.PagingData{After: "3", Link: "https://api.hubapi.com/crm/v3/objects/p12345_cars?after=3"}},
+			},
+		},
+		{
+			name: "Create",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return s.Create(&car{Name: hubspot.NewString("car")})
+			},
+			status:   http.StatusCreated,
+			response: `{"id":"1","properties":{"name":"car"}}`,
+			wantReq:  objectRequest{Method: http.MethodPost, URL: "/crm/v3/objects/p12345_cars", Body: `{"properties":{"name":"car"}}`},
+			want:     &hubspot.ResponseResource{ID: "1", Properties: &car{Name: hubspot.NewString("car")}},
+		},
+		{
+			name: "Update",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return s.Update("1", &car{Model: hubspot.NewString("y")})
+			},
+			status:   http.StatusOK,
+			response: `{"id":"1","properties":{"name":"car","model":"y"}}`,
+			wantReq:  objectRequest{Method: http.MethodPatch, URL: "/crm/v3/objects/p12345_cars/1", Body: `{"properties":{"model":"y"}}`},
+			want:     &hubspot.ResponseResource{ID: "1", Properties: &car{Name: hubspot.NewString("car"), Model: hubspot.NewString("y")}},
+		},
+		{
+			name: "Archive",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return nil, s.Archive("1")
+			},
+			status:  http.StatusNoContent,
+			wantReq: objectRequest{Method: http.MethodDelete, URL: "/crm/v3/objects/p12345_cars/1"},
+		},
+		{
+			name: "Search",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return s.Search(&hubspot.ObjectSearchRequest{SearchOptions: hubspot.SearchOptions{Query: "car", Limit: 1}})
+			},
+			status:   http.StatusOK,
+			response: `{"total":2,"results":[{"id":"1","properties":{"name":"car"}}],"paging":{"next":{"after":"1"}}}`,
+			wantReq:  objectRequest{Method: http.MethodPost, URL: "/crm/v3/objects/p12345_cars/search", Body: `{"query":"car","limit":1}`},
+			want: &hubspot.ObjectList{
+				Total:   2,
+				Results: []*hubspot.ResponseResource{{ID: "1", Properties: map[string]interface{}{"name": "car"}}},
+				Paging:  &hubspot.Paging{Next: &hubspot.PagingData{After: "1"}},
+			},
+		},
+		{
+			name: "AssociateAnotherObj",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return s.AssociateAnotherObj("1", &hubspot.AssociationConfig{ToObject: hubspot.ObjectTypeContact, ToObjectID: "2", Type: "car_to_contact"})
+			},
+			status:   http.StatusOK,
+			response: `{"id":"1"}`,
+			wantReq:  objectRequest{Method: http.MethodPut, URL: "/crm/v3/objects/p12345_cars/1/associations/contacts/2/car_to_contact"},
+			want:     &hubspot.ResponseResource{ID: "1"},
+		},
+		{
+			name: "BatchRead",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return s.BatchRead([]string{"car-1"}, &hubspot.BatchReadOption{Properties: []string{"name"}, IDProperty: "vin"})
+			},
+			status:   http.StatusOK,
+			response: `{"status":"COMPLETE","results":[{"id":"1","properties":{"name":"car"}}]}`,
+			wantReq:  objectRequest{Method: http.MethodPost, URL: "/crm/v3/objects/p12345_cars/batch/read", Body: `{"properties":["name"],"idProperty":"vin","inputs":[{"id":"car-1"}]}`},
+			want: &hubspot.BatchResponse{
+				Status:  "COMPLETE",
+				Results: []*hubspot.ResponseResource{{ID: "1", Properties: map[string]interface{}{"name": "car"}}},
+			},
+		},
+		{
+			name: "BatchCreate",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return s.BatchCreate([]interface{}{&car{Name: hubspot.NewString("car")}})
+			},
+			status:   http.StatusCreated,
+			response: `{"status":"COMPLETE","results":[{"id":"1","properties":{"name":"car"}}]}`,
+			wantReq:  objectRequest{Method: http.MethodPost, URL: "/crm/v3/objects/p12345_cars/batch/create", Body: `{"inputs":[{"properties":{"name":"car"}}]}`},
+			want: &hubspot.BatchResponse{
+				Status:  "COMPLETE",
+				Results: []*hubspot.ResponseResource{{ID: "1", Properties: map[string]interface{}{"name": "car"}}},
+			},
+		},
+		{
+			name: "BatchUpdate",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return s.BatchUpdate([]*hubspot.BatchUpdateInput{{ID: "1", Properties: &car{Model: hubspot.NewString("y")}}})
+			},
+			status:   http.StatusOK,
+			response: `{"status":"COMPLETE","results":[{"id":"1","properties":{"model":"y"}}]}`,
+			wantReq:  objectRequest{Method: http.MethodPost, URL: "/crm/v3/objects/p12345_cars/batch/update", Body: `{"inputs":[{"id":"1","properties":{"model":"y"}}]}`},
+			want: &hubspot.BatchResponse{
+				Status:  "COMPLETE",
+				Results: []*hubspot.ResponseResource{{ID: "1", Properties: map[string]interface{}{"model": "y"}}},
+			},
+		},
+		{
+			name: "BatchArchive",
+			call: func(s hubspot.ObjectService) (interface{}, error) {
+				return nil, s.BatchArchive([]string{"1", "2"})
+			},
+			status:  http.StatusNoContent,
+			wantReq: objectRequest{Method: http.MethodPost, URL: "/crm/v3/objects/p12345_cars/batch/archive", Body: `{"inputs":[{"id":"1"},{"id":"2"}]}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotReq objectRequest
+			c := newObjectsClient(t, tt.status, tt.response, &gotReq)
+
+			got, err := tt.call(c.CRM.Objects("p12345_cars"))
+			if err != nil {
+				t.Fatalf("%s() error: %s", tt.name, err)
+			}
+			if diff := cmp.Diff(tt.wantReq, gotReq); diff != "" {
+				t.Errorf("request mismatch (-want +got):%s", diff)
+			}
+			if tt.want == nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("%s() response mismatch (-want +got):%s", tt.name, diff)
+			}
+		})
+	}
+}
@@ -1,28 +1,83 @@
 package hubspot
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 )
 
 type Option func(c *Client)
 
-// TODO: Add WithRetryConfig
-
 func WithAPIVersion(version string) Option {
 	return func(c *Client) {
 		c.apiVersion = version
 	}
 }
 
+// WithHTTPClient sends the requests with the given HTTP client, including the token requests of SetOAuth.
 func WithHTTPClient(client *http.Client) Option {
 	return func(c *Client) {
 		c.HTTPClient = client
+		if otm, ok := c.oauthTokenManager(); ok {
+			otm.HTTPClient = client
+		}
 	}
 }
 
+// WithBaseURL sends the requests to the given URL instead of https://api.hubapi.com, including the token requests of SetOAuth.
 func WithBaseURL(url *url.URL) Option {
 	return func(c *Client) {
 		c.baseURL = url
+		if otm, ok := c.oauthTokenManager(); ok {
+			otm.oauthPath = fmt.Sprintf("%s/%s", url.String(), oauthTokenPath)
+		}
+	}
+}
+
+// WithRetryConfig enables the automatic retries of failed requests.
+// See RetryConfig for which requests are retried.
+func WithRetryConfig(config *RetryConfig) Option {
+	return func(c *Client) {
+		c.retryConfig = config
+	}
+}
+
+// WithRateLimiter throttles the requests of the client with the given RateLimiter.
+// Pass the same RateLimiter to every Client that uses the same token, since HubSpot limits the requests per token.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// WithMiddleware registers middlewares around the execution of the requests.
+// They are called in the given order, after the middlewares registered before.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// WithLogger emits a LogEvent to the logger for every attempt of a request.
+// Authorization headers, API keys and OAuth secrets are always redacted.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logging.logger = logger
+	}
+}
+
+// WithLogBodies adds the request and response bodies to the log events emitted with WithLogger.
+// The values of DefaultMaskedProperties and of the given properties are masked.
+func WithLogBodies(maskedProperties ...string) Option {
+	return func(c *Client) {
+		c.logging.bodies = true
+		c.logging.masked = newMaskedProperties(maskedProperties)
+	}
+}
+
+// WithTracer traces the API calls with the given Tracer.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		c.tracer = tracer
 	}
 }
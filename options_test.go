@@ -33,3 +33,47 @@ func TestWithBaseURL(t *testing.T) {
 		t.Errorf("WithBaseURL() result mismatch: (-want +got):%s", diff)
 	}
 }
+
+func TestOptions_OAuth(t *testing.T) {
+	// The options are applied after the authentication method, and the token requests of SetOAuth follow them.
+	var gotURLs []string
+	c, _ := hubspot.NewClient(hubspot.SetOAuth(&hubspot.OAuthConfig{
+		GrantType:    hubspot.GrantTypeRefreshToken,
+		ClientID:     "client_id",
+		ClientSecret: "client_secret",
+		RefreshToken: "refresh_token",
+	}), hubspot.WithBaseURL(&url.URL{Scheme: "http", Host: "example.com"}), hubspot.WithHTTPClient(&http.Client{
+		Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+			gotURLs = append(gotURLs, req.URL.String())
+			body := `{}`
+			if req.URL.Path == "/oauth/v1/token" {
+				body = `{"access_token":"access_token","refresh_token":"refresh_token","expires_in":1800}`
+			}
+			return hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: http.StatusOK, Body: []byte(body)}).Transport.RoundTrip(req)
+		}),
+	}))
+
+	if err := c.Get("crm/v3/objects/contacts/1", nil, nil); err != nil {
+		t.Fatalf("Get() error: %s", err)
+	}
+	want := []string{"http://example.com/oauth/v1/token", "http://example.com/crm/v3/objects/contacts/1"}
+	if diff := cmp.Diff(want, gotURLs); diff != "" {
+		t.Errorf("request URLs mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestWithRetryConfig(t *testing.T) {
+	want := &hubspot.RetryConfig{MaxAttempts: 5}
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithRetryConfig(want))
+	if want != c.ExportGetRetryConfig() {
+		t.Errorf("WithRetryConfig() result mismatch: want %v got %v", want, c.ExportGetRetryConfig())
+	}
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	want := hubspot.NewRateLimiter(nil)
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithRateLimiter(want))
+	if want != c.ExportGetRateLimiter() {
+		t.Errorf("WithRateLimiter() result mismatch: want %v got %v", want, c.ExportGetRateLimiter())
+	}
+}
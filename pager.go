@@ -0,0 +1,239 @@
+package hubspot
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// ErrSearchResultsCap is the error of a search pager which has returned the 10,000 results HubSpot returns for a search at most
+// while more objects match. Use NewPartitionedSearchPager, or SearchAll of the services, to search beyond them.
+var ErrSearchResultsCap = errors.New("the search has more than 10,000 results, use NewPartitionedSearchPager to get them all")
+
+// PageFunc fetches the page of at most limit objects at the cursor after, which is empty for the first page.
+// It returns the objects and the cursor of the next page, which is empty at the last page.
+// A limit of 0 leaves the page size to the endpoint.
+type PageFunc func(ctx context.Context, after string, limit int) ([]*ResponseResource, string, error)
+
+// PagerOption is a set of options to walk through the pages of a list or search endpoint.
+type PagerOption struct {
+	// PageSize is the number of objects requested per page, 100 at most for lists and 200 for searches.
+	// The limit of the request, or else the default of the endpoint, is used when it is 0.
+	PageSize int
+	// MaxItems is the maximum number of objects to be returned. There is no limit when it is 0.
+	MaxItems int
+	// After is the cursor to resume from, Pager.Cursor of a previous pager.
+	After string
+}
+
+// Pager walks through the pages of a list or search endpoint until they are exhausted, e.g.
+//
+//	pager := hubspot.NewSearchPager(client.CRM.Objects(hubspot.ObjectTypeContact), req, nil)
+//	for pager.Next(ctx) {
+//		contact := pager.Object()
+//	}
+//	if err := pager.Err(); err != nil {
+//		return err
+//	}
+//
+// The pages of a pager returned by a service are traced as a single operation, which ends when Next returns false.
+type Pager struct {
+	fetch    PageFunc
+	pageSize int
+	maxItems int
+
+	// trace starts the span of the pages, and spanCtx holds it until endSpan is called.
+	trace   func(ctx context.Context) (context.Context, func(err error))
+	spanCtx context.Context
+	endSpan func(err error)
+
+	page  []*ResponseResource
+	index int
+	// cursor is the cursor of the current page, and next the cursor of the page after it.
+	cursor  string
+	next    string
+	fetched bool
+	count   int
+	err     error
+}
+
+// NewPager returns a pager fetching the pages with fetch.
+func NewPager(fetch PageFunc, option *PagerOption) *Pager {
+	if option == nil {
+		option = &PagerOption{}
+	}
+	return &Pager{
+		fetch:    fetch,
+		pageSize: option.PageSize,
+		maxItems: option.MaxItems,
+		next:     option.After,
+		index:    -1,
+	}
+}
+
+// NewListPager returns a pager listing the objects of a service, e.g. client.CRM.Objects(hubspot.ObjectTypeContact).
+// option.Limit and option.After are overridden by the pager option.
+func NewListPager(s ObjectService, option *ObjectListOption, pagerOption *PagerOption) *Pager {
+	var opt ObjectListOption
+	if option != nil {
+		opt = *option
+	}
+	if pagerOption == nil || pagerOption.PageSize == 0 {
+		pagerOption = withPageSize(pagerOption, opt.Limit)
+	}
+	p := NewPager(func(ctx context.Context, after string, limit int) ([]*ResponseResource, string, error) {
+		opt.After, opt.Limit = after, limit
+		list, err := s.ListWithContext(ctx, &opt)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Results, list.nextCursor(), nil
+	}, pagerOption)
+	p.trace = objectsTracer(s, "CRM.Objects.List")
+	return p
+}
+
+// NewSearchPager returns a pager searching the objects of a service, e.g. client.CRM.Objects(hubspot.ObjectTypeContact).
+// req.Limit and req.After are overridden by the pager option.
+// HubSpot returns the first 10,000 results of a search at most, and the pager stops with ErrSearchResultsCap
+// when more objects match.
+func NewSearchPager(s ObjectService, req *ObjectSearchRequest, pagerOption *PagerOption) *Pager {
+	var r ObjectSearchRequest
+	if req != nil {
+		r = *req
+	}
+	if pagerOption == nil || pagerOption.PageSize == 0 {
+		pagerOption = withPageSize(pagerOption, r.Limit)
+	}
+	p := NewPager(func(ctx context.Context, after string, limit int) ([]*ResponseResource, string, error) {
+		// The cursor of a search is the offset of the page, which HubSpot rejects beyond the cap.
+		offset, _ := strconv.Atoi(after)
+		if offset >= maxSearchResults {
+			return nil, "", ErrSearchResultsCap
+		}
+		if limit > 0 && offset+limit > maxSearchResults {
+			limit = maxSearchResults - offset
+		}
+		r.After, r.Limit = after, limit
+		list, err := s.SearchWithContext(ctx, &r)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Results, list.nextCursor(), nil
+	}, pagerOption)
+	p.trace = objectsTracer(s, "CRM.Objects.Search")
+	return p
+}
+
+func withPageSize(option *PagerOption, pageSize int) *PagerOption {
+	opt := PagerOption{}
+	if option != nil {
+		opt = *option
+	}
+	opt.PageSize = pageSize
+	return &opt
+}
+
+// Next advances to the next object, fetching the next page when the current one has been read.
+// It returns false when the pages are exhausted, MaxItems objects have been returned or an error has occurred.
+func (p *Pager) Next(ctx context.Context) bool {
+	if p.err != nil || (p.maxItems > 0 && p.count >= p.maxItems) {
+		return p.end()
+	}
+	for p.index+1 >= len(p.page) {
+		if p.fetched && p.next == "" {
+			return p.end()
+		}
+		limit := p.pageSize
+		if limit > 0 && p.maxItems > 0 && p.maxItems-p.count < limit {
+			limit = p.maxItems - p.count
+		}
+		page, next, err := p.fetch(p.traced(ctx), p.next, limit)
+		if err != nil {
+			p.err = err
+			return p.end()
+		}
+		p.page, p.index, p.cursor, p.next, p.fetched = page, -1, p.next, next, true
+	}
+	p.index++
+	p.count++
+	return true
+}
+
+// traced returns the context to fetch a page with, within the span of the pages when the pager is traced.
+func (p *Pager) traced(ctx context.Context) context.Context {
+	if p.trace == nil {
+		return ctx
+	}
+	if p.spanCtx == nil {
+		p.spanCtx, p.endSpan = p.trace(ctx)
+		return p.spanCtx
+	}
+	return &valuesContext{Context: ctx, values: p.spanCtx}
+}
+
+// end ends the span of the pages, if any, and returns false for Next.
+func (p *Pager) end() bool {
+	if p.endSpan != nil {
+		p.endSpan(p.err)
+		p.endSpan = nil
+	}
+	return false
+}
+
+// valuesContext is a context whose values are looked up in values first, e.g. the span of the pages started
+// with the context of the first page, and whose cancellation is the one of the embedded context.
+type valuesContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c *valuesContext) Value(key interface{}) interface{} {
+	if v := c.values.Value(key); v != nil {
+		return v
+	}
+	return c.Context.Value(key)
+}
+
+// objectsTracer returns the function starting the span of the pages of a service, when it is an ObjectServiceOp.
+func objectsTracer(s ObjectService, name string) func(ctx context.Context) (context.Context, func(err error)) {
+	op, ok := s.(*ObjectServiceOp)
+	if !ok {
+		return nil
+	}
+	return op.client.operationTracer(name, string(op.objectType))
+}
+
+// Object returns the current object.
+func (p *Pager) Object() *ResponseResource {
+	if p.index < 0 || p.index >= len(p.page) {
+		return nil
+	}
+	return p.page[p.index]
+}
+
+// Err returns the error which has stopped the pager, if any.
+func (p *Pager) Err() error {
+	return p.err
+}
+
+// Done reports whether all the pages have been read.
+func (p *Pager) Done() bool {
+	return p.fetched && p.next == "" && p.index+1 >= len(p.page)
+}
+
+// Cursor returns the cursor to resume from with PagerOption.After, which is empty to start from the first page.
+// When the current page has not been read entirely, it is the cursor of the current page, whose objects are returned again when resuming.
+func (p *Pager) Cursor() string {
+	if p.index+1 < len(p.page) {
+		return p.cursor
+	}
+	return p.next
+}
+
+func (l *ObjectList) nextCursor() string {
+	if l.Paging == nil || l.Paging.Next == nil {
+		return ""
+	}
+	return l.Paging.Next.After
+}
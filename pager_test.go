@@ -0,0 +1,221 @@
+package hubspot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+type pageRequest struct {
+	After string
+	Limit int
+}
+
+// fakePages serves the objects 1 to total in pages, the cursor being the offset of the page.
+func fakePages(total int, requests *[]pageRequest) hubspot.PageFunc {
+	return func(ctx context.Context, after string, limit int) ([]*hubspot.ResponseResource, string, error) {
+		*requests = append(*requests, pageRequest{After: after, Limit: limit})
+		if limit == 0 {
+			limit = 10
+		}
+		offset, _ := strconv.Atoi(after)
+		var page []*hubspot.ResponseResource
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, &hubspot.ResponseResource{ID: fmt.Sprint(i + 1)})
+		}
+		if offset+limit >= total {
+			return page, "", nil
+		}
+		return page, fmt.Sprint(offset + limit), nil
+	}
+}
+
+func TestPager(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		option       *hubspot.PagerOption
+		wantIDs      int
+		wantRequests []pageRequest
+		wantCursor   string
+		wantDone     bool
+	}{
+		{
+			name:         "Walk every page",
+			total:        25,
+			option:       &hubspot.PagerOption{PageSize: 10},
+			wantIDs:      25,
+			wantRequests: []pageRequest{{After: "", Limit: 10}, {After: "10", Limit: 10}, {After: "20", Limit: 10}},
+			wantDone:     true,
+		},
+		{
+			name:         "Default page size",
+			total:        15,
+			wantIDs:      15,
+			wantRequests: []pageRequest{{After: "", Limit: 0}, {After: "10", Limit: 0}},
+			wantDone:     true,
+		},
+		{
+			name:         "Stop at max items",
+			total:        25,
+			option:       &hubspot.PagerOption{PageSize: 10, MaxItems: 15},
+			wantIDs:      15,
+			wantRequests: []pageRequest{{After: "", Limit: 10}, {After: "10", Limit: 5}},
+			wantCursor:   "15",
+		},
+		{
+			name:         "Resume from a cursor",
+			total:        25,
+			option:       &hubspot.PagerOption{PageSize: 10, After: "20"},
+			wantIDs:      5,
+			wantRequests: []pageRequest{{After: "20", Limit: 10}},
+			wantDone:     true,
+		},
+		{
+			name:         "No objects",
+			total:        0,
+			wantRequests: []pageRequest{{After: "", Limit: 0}},
+			wantDone:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []pageRequest
+			p := hubspot.NewPager(fakePages(tt.total, &requests), tt.option)
+
+			var n int
+			for p.Next(context.Background()) {
+				n++
+				if got := p.Object().ID; got == "" {
+					t.Errorf("Object() returned no object")
+				}
+			}
+			if err := p.Err(); err != nil {
+				t.Fatalf("Err() error: %s", err)
+			}
+			if n != tt.wantIDs {
+				t.Errorf("objects mismatch: want %d got %d", tt.wantIDs, n)
+			}
+			if diff := cmp.Diff(tt.wantRequests, requests); diff != "" {
+				t.Errorf("requests mismatch (-want +got):%s", diff)
+			}
+			if got := p.Cursor(); got != tt.wantCursor {
+				t.Errorf("Cursor() mismatch: want %q got %q", tt.wantCursor, got)
+			}
+			if got := p.Done(); got != tt.wantDone {
+				t.Errorf("Done() mismatch: want %t got %t", tt.wantDone, got)
+			}
+		})
+	}
+}
+
+func TestPager_Error(t *testing.T) {
+	wantErr := errors.New("failed")
+	p := hubspot.NewPager(func(ctx context.Context, after string, limit int) ([]*hubspot.ResponseResource, string, error) {
+		if after != "" {
+			return nil, "", wantErr
+		}
+		return []*hubspot.ResponseResource{{ID: "1"}, {ID: "2"}}, "2", nil
+	}, nil)
+
+	ctx := context.Background()
+	if !p.Next(ctx) || p.Cursor() != "" {
+		t.Fatalf("first object mismatch: want the current page cursor got %q", p.Cursor())
+	}
+	if !p.Next(ctx) || p.Cursor() != "2" {
+		t.Fatalf("second object mismatch: want the next page cursor got %q", p.Cursor())
+	}
+	if p.Next(ctx) {
+		t.Fatal("Next() mismatch: want false got true")
+	}
+	if !errors.Is(p.Err(), wantErr) {
+		t.Errorf("Err() mismatch: want %s got %v", wantErr, p.Err())
+	}
+	if p.Cursor() != "2" {
+		t.Errorf("Cursor() mismatch: want 2 got %q", p.Cursor())
+	}
+}
+
+func TestNewSearchPager(t *testing.T) {
+	var bodies []map[string]interface{}
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(&http.Client{
+		Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &body)
+			bodies = append(bodies, body)
+			response := `{"total":3,"results":[{"id":"1"},{"id":"2"}],"paging":{"next":{"after":"2"}}}`
+			if body["after"] == "2" {
+				response = `{"total":3,"results":[{"id":"3"}]}`
+			}
+			return hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: http.StatusOK, Body: []byte(response)}).Transport.RoundTrip(req)
+		}),
+	}))
+
+	req := &hubspot.ObjectSearchRequest{SearchOptions: hubspot.SearchOptions{Query: "example", Limit: 2}}
+	p := hubspot.NewSearchPager(c.CRM.Objects(hubspot.ObjectTypeContact), req, nil)
+	var ids []string
+	for p.Next(context.Background()) {
+		ids = append(ids, p.Object().ID)
+	}
+	if err := p.Err(); err != nil {
+		t.Fatalf("Err() error: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"1", "2", "3"}, ids); diff != "" {
+		t.Errorf("objects mismatch (-want +got):%s", diff)
+	}
+	wantBodies := []map[string]interface{}{
+		{"query": "example", "limit": float64(2)},
+		{"query": "example", "limit": float64(2), "after": "2"},
+	}
+	if diff := cmp.Diff(wantBodies, bodies); diff != "" {
+		t.Errorf("request bodies mismatch (-want +got):%s", diff)
+	}
+	if req.After != "" {
+		t.Errorf("request has been modified: after %q", req.After)
+	}
+}
+
+func TestNewSearchPager_ResultsCap(t *testing.T) {
+	defer hubspot.MockMaxSearchResults(5)()
+
+	var requests []pageRequest
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(&http.Client{
+		Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+			var body hubspot.SearchOptions
+			b, _ := ioutil.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &body)
+			// 8 objects match the search.
+			page, next, _ := fakePages(8, &requests)(req.Context(), body.After, body.Limit)
+			b, _ = json.Marshal(&hubspot.ObjectList{Results: page, Paging: &hubspot.Paging{Next: &hubspot.PagingData{After: next}}})
+			return hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: http.StatusOK, Body: b}).Transport.RoundTrip(req)
+		}),
+	}))
+
+	p := hubspot.NewSearchPager(c.CRM.Objects(hubspot.ObjectTypeContact), nil, &hubspot.PagerOption{PageSize: 2})
+	var ids []string
+	for p.Next(context.Background()) {
+		ids = append(ids, p.Object().ID)
+	}
+	if err := p.Err(); !errors.Is(err, hubspot.ErrSearchResultsCap) {
+		t.Errorf("Err() mismatch: want ErrSearchResultsCap got %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"1", "2", "3", "4", "5"}, ids); diff != "" {
+		t.Errorf("objects mismatch (-want +got):%s", diff)
+	}
+	// The last page is shortened so as not to exceed the cap.
+	wantRequests := []pageRequest{{After: "", Limit: 2}, {After: "2", Limit: 2}, {After: "4", Limit: 1}}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):%s", diff)
+	}
+}
@@ -0,0 +1,171 @@
+package hubspot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPortalIdleTimeout = 30 * time.Minute
+)
+
+// PortalPoolConfig is the configuration of a PortalPool.
+type PortalPoolConfig struct {
+	// App is the OAuth app installed on the portals.
+	App *OAuthAppConfig
+	// TokenStore returns the store of the token of a portal. It must return the same store for a portal every time,
+	// e.g. a FileTokenStore in a directory per portal, and the store must hold the token saved at install.
+	TokenStore func(portalID int64) TokenStore
+	// HTTPClient is shared by the clients of all the portals. http.DefaultClient is used when it is nil.
+	HTTPClient *http.Client
+	// RateLimit is the configuration of the RateLimiter of each portal. The default one is used when it is nil.
+	RateLimit *RateLimitConfig
+	// IdleTimeout is how long a client is kept while unused. It is 30 minutes by default.
+	IdleTimeout time.Duration
+	// Options are applied to every client.
+	Options []Option
+}
+
+// PortalPool keeps a Client per portal an OAuth app is installed on.
+// The clients are created on first use and evicted once idle. They share the HTTP client and the base URL of the app,
+// and each portal has its own RateLimiter since HubSpot limits the calls per portal.
+// The idle clients are evicted by Client, so the pool does not run any goroutine, and the clients of a pool
+// which is no longer called stay in memory until it is released.
+// It is safe for concurrent use.
+//
+//	client, err := pool.Client(ctx, event.PortalID)
+type PortalPool struct {
+	config *PortalPoolConfig
+
+	mu      sync.Mutex
+	portals map[int64]*portalClient
+}
+
+type portalClient struct {
+	client   *Client
+	lastUsed time.Time
+}
+
+// NewPortalPool returns a new PortalPool.
+func NewPortalPool(config *PortalPoolConfig) (*PortalPool, error) {
+	if config == nil || config.App == nil {
+		return nil, errors.New("the OAuth app is not set")
+	}
+	if config.TokenStore == nil {
+		return nil, errors.New("the token store is not set")
+	}
+	return &PortalPool{
+		config:  config,
+		portals: map[int64]*portalClient{},
+	}, nil
+}
+
+// Client returns the client of the portal, creating it if needed, and evicts the clients which have been idle.
+// It fails when no token has been stored for the portal, i.e. the app has not been installed on it.
+func (p *PortalPool) Client(ctx context.Context, portalID int64) (*Client, error) {
+	if client, ok := p.client(portalID); ok {
+		return client, nil
+	}
+
+	// The token is loaded without holding the lock, so that a slow store does not hold up the other portals.
+	store := p.config.TokenStore(portalID)
+	token, err := store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the token of portal %d: %w", portalID, err)
+	}
+	if token == nil || token.RefreshToken == "" {
+		return nil, fmt.Errorf("no token has been stored for portal %d", portalID)
+	}
+
+	opts := []Option{
+		WithRateLimiter(NewRateLimiter(p.config.RateLimit)),
+	}
+	if p.config.HTTPClient != nil {
+		opts = append(opts, WithHTTPClient(p.config.HTTPClient))
+	}
+	if p.config.App.BaseURL != nil {
+		opts = append(opts, WithBaseURL(p.config.App.BaseURL))
+	}
+	opts = append(opts, p.config.Options...)
+	client, err := NewClient(SetOAuth(p.config.App.RefreshConfig(token.RefreshToken), WithTokenStore(store)), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Another call may have created the client of the portal meanwhile.
+	if pc, ok := p.portals[portalID]; ok {
+		pc.lastUsed = timeNow()
+		return pc.client, nil
+	}
+	p.portals[portalID] = &portalClient{client: client, lastUsed: timeNow()}
+	return client, nil
+}
+
+// client returns the client of the portal if the pool has it, after evicting the idle clients.
+func (p *PortalPool) client(portalID int64) (*Client, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := timeNow()
+	p.evictIdle(now)
+	pc, ok := p.portals[portalID]
+	if !ok {
+		return nil, false
+	}
+	pc.lastUsed = now
+	return pc.client, true
+}
+
+// Evict removes the client of the portal, e.g. when the app has been uninstalled from it.
+func (p *PortalPool) Evict(portalID int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.portals, portalID)
+}
+
+// Len returns the number of the clients in the pool.
+func (p *PortalPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.portals)
+}
+
+// evictIdle removes the clients which have not been used within the idle timeout. p.mu must be held.
+func (p *PortalPool) evictIdle(now time.Time) {
+	timeout := p.config.IdleTimeout
+	if timeout <= 0 {
+		timeout = defaultPortalIdleTimeout
+	}
+	for id, pc := range p.portals {
+		if now.Sub(pc.lastUsed) > timeout {
+			delete(p.portals, id)
+		}
+	}
+}
+
+// WebhookPortalIDs returns the IDs of the portals the events of a webhook payload come from, in order of appearance.
+// Use them to get the clients of the portals from a PortalPool.
+func WebhookPortalIDs(payload []byte) ([]int64, error) {
+	var events []struct {
+		PortalID int64 `json:"portalId"`
+	}
+	if err := json.Unmarshal(payload, &events); err != nil {
+		return nil, err
+	}
+	seen := map[int64]bool{}
+	var ids []int64
+	for _, e := range events {
+		if !seen[e.PortalID] {
+			seen[e.PortalID] = true
+			ids = append(ids, e.PortalID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,197 @@
+package hubspot_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPortalPool_Client(t *testing.T) {
+	stores := map[int64]hubspot.TokenStore{
+		62515: hubspot.NewMemoryTokenStore(),
+		62516: hubspot.NewMemoryTokenStore(),
+	}
+	for id, store := range stores {
+		token := &hubspot.OAuthToken{
+			AccessToken:  "access_token_" + strconv.FormatInt(id, 10),
+			RefreshToken: "refresh_token",
+			Expiry:       time.Now().Add(time.Hour),
+		}
+		if err := store.Save(context.Background(), token); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var gotAuthorization []string
+	pool, err := hubspot.NewPortalPool(&hubspot.PortalPoolConfig{
+		App: &hubspot.OAuthAppConfig{ClientID: "client_id", ClientSecret: "client_secret"},
+		TokenStore: func(portalID int64) hubspot.TokenStore {
+			if store, ok := stores[portalID]; ok {
+				return store
+			}
+			return hubspot.NewMemoryTokenStore()
+		},
+		HTTPClient: &http.Client{
+			Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+				gotAuthorization = append(gotAuthorization, req.Header.Get("Authorization"))
+				return hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: http.StatusOK, Body: []byte(`{}`)}).Transport.RoundTrip(req)
+			}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewPortalPool() error: %s", err)
+	}
+
+	first, err := pool.Client(context.Background(), 62515)
+	if err != nil {
+		t.Fatalf("Client() error: %s", err)
+	}
+	again, err := pool.Client(context.Background(), 62515)
+	if err != nil {
+		t.Fatalf("Client() error: %s", err)
+	}
+	if first != again {
+		t.Error("Client() must return the same client for a portal")
+	}
+	other, err := pool.Client(context.Background(), 62516)
+	if err != nil {
+		t.Fatalf("Client() error: %s", err)
+	}
+	if first == other {
+		t.Error("Client() must return a client per portal")
+	}
+	if first.ExportGetRateLimiter() == other.ExportGetRateLimiter() {
+		t.Error("the portals must not share a rate limiter")
+	}
+
+	for _, c := range []*hubspot.Client{first, other} {
+		if err := c.Get("crm/v3/objects/contacts/1", nil, nil); err != nil {
+			t.Fatalf("Get() error: %s", err)
+		}
+	}
+	want := []string{"Bearer access_token_62515", "Bearer access_token_62516"}
+	if diff := cmp.Diff(want, gotAuthorization); diff != "" {
+		t.Errorf("Authorization mismatch (-want +got):%s", diff)
+	}
+
+	_, err = pool.Client(context.Background(), 1)
+	if wantErr := errors.New("no token has been stored for portal 1"); !reflect.DeepEqual(wantErr, err) {
+		t.Errorf("Client() error mismatch: want %s got %s", wantErr, err)
+	}
+
+	pool.Evict(62515)
+	if got := pool.Len(); got != 1 {
+		t.Errorf("Len() mismatch after Evict(): want 1 got %d", got)
+	}
+}
+
+func TestPortalPool_IdleTimeout(t *testing.T) {
+	store := hubspot.NewMemoryTokenStore()
+	if err := store.Save(context.Background(), &hubspot.OAuthToken{AccessToken: "access_token", RefreshToken: "refresh_token"}); err != nil {
+		t.Fatal(err)
+	}
+	pool, _ := hubspot.NewPortalPool(&hubspot.PortalPoolConfig{
+		App:         &hubspot.OAuthAppConfig{ClientID: "client_id", ClientSecret: "client_secret"},
+		TokenStore:  func(int64) hubspot.TokenStore { return store },
+		IdleTimeout: time.Millisecond,
+	})
+
+	first, _ := pool.Client(context.Background(), 62515)
+	time.Sleep(5 * time.Millisecond)
+	second, _ := pool.Client(context.Background(), 62516)
+	if got := pool.Len(); got != 1 {
+		t.Errorf("Len() mismatch: the idle client must be evicted: want 1 got %d", got)
+	}
+	if again, _ := pool.Client(context.Background(), 62515); again == first || again == second {
+		t.Error("Client() must create a new client for an evicted portal")
+	}
+}
+
+// blockingTokenStore is a TokenStore whose Load waits until release is closed.
+type blockingTokenStore struct {
+	hubspot.TokenStore
+	loading chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingTokenStore) Load(ctx context.Context) (*hubspot.OAuthToken, error) {
+	close(s.loading)
+	<-s.release
+	return s.TokenStore.Load(ctx)
+}
+
+func TestPortalPool_ConcurrentLoad(t *testing.T) {
+	token := &hubspot.OAuthToken{AccessToken: "access_token", RefreshToken: "refresh_token", Expiry: time.Now().Add(time.Hour)}
+	fast := hubspot.NewMemoryTokenStore()
+	slow := &blockingTokenStore{TokenStore: hubspot.NewMemoryTokenStore(), loading: make(chan struct{}), release: make(chan struct{})}
+	for _, store := range []hubspot.TokenStore{fast, slow.TokenStore} {
+		if err := store.Save(context.Background(), token); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var gotURL string
+	pool, _ := hubspot.NewPortalPool(&hubspot.PortalPoolConfig{
+		App: &hubspot.OAuthAppConfig{ClientID: "client_id", ClientSecret: "client_secret", BaseURL: &url.URL{Scheme: "http", Host: "localhost:8080"}},
+		TokenStore: func(portalID int64) hubspot.TokenStore {
+			if portalID == 62515 {
+				return slow
+			}
+			return fast
+		},
+		HTTPClient: &http.Client{
+			Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+				gotURL = req.URL.String()
+				return hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: http.StatusOK, Body: []byte(`{}`)}).Transport.RoundTrip(req)
+			}),
+		},
+	})
+
+	done := make(chan *hubspot.Client)
+	go func() {
+		c, _ := pool.Client(context.Background(), 62515)
+		done <- c
+	}()
+	<-slow.loading
+
+	// The client of another portal is returned while the token of the first one is being loaded.
+	other, err := pool.Client(context.Background(), 62516)
+	if err != nil {
+		t.Fatalf("Client() error: %s", err)
+	}
+	close(slow.release)
+	first := <-done
+	if first == nil || first == other {
+		t.Error("Client() must return a client per portal")
+	}
+	if again, _ := pool.Client(context.Background(), 62515); again != first {
+		t.Error("Client() must return the same client for a portal")
+	}
+
+	// The clients use the base URL of the app.
+	if err := other.Get("crm/v3/objects/contacts/1", nil, nil); err != nil {
+		t.Fatalf("Get() error: %s", err)
+	}
+	if want := "http://localhost:8080/crm/v3/objects/contacts/1"; want != gotURL {
+		t.Errorf("request URL mismatch: want %s got %s", want, gotURL)
+	}
+}
+
+func TestWebhookPortalIDs(t *testing.T) {
+	payload := []byte(`[{"objectId":1246965,"propertyName":"lifecyclestage","propertyValue":"subscriber","changeSource":"ACADEMY","eventId":3816279340,"subscriptionId":25,"portalId":33,"appId":1160452,"occurredAt":1462216307945,"subscriptionType":"contact.propertyChange","attemptNumber":0},{"objectId":1246978,"changeSource":"IMPORT","eventId":3816279480,"subscriptionId":22,"portalId":34,"appId":1160452,"occurredAt":1462216307945,"subscriptionType":"contact.creation","attemptNumber":0},{"objectId":1246979,"eventId":3816279481,"subscriptionId":22,"portalId":33,"appId":1160452,"occurredAt":1462216307945,"subscriptionType":"contact.creation","attemptNumber":0}]`)
+
+	got, err := hubspot.WebhookPortalIDs(payload)
+	if err != nil {
+		t.Fatalf("WebhookPortalIDs() error: %s", err)
+	}
+	if diff := cmp.Diff([]int64{33, 34}, got); diff != "" {
+		t.Errorf("WebhookPortalIDs() result mismatch (-want +got):%s", diff)
+	}
+}
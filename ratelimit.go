@@ -0,0 +1,273 @@
+package hubspot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// HubSpot rate limit headers.
+	// Reference: https://developers.hubspot.com/docs/api/usage-details
+	headerRateLimitMax            = "X-HubSpot-RateLimit-Max"
+	headerRateLimitRemaining      = "X-HubSpot-RateLimit-Remaining"
+	headerRateLimitInterval       = "X-HubSpot-RateLimit-Interval-Milliseconds"
+	headerRateLimitDaily          = "X-HubSpot-RateLimit-Daily"
+	headerRateLimitDailyRemaining = "X-HubSpot-RateLimit-Daily-Remaining"
+
+	defaultRateLimitMaxRequests       = 100
+	defaultRateLimitInterval          = 10 * time.Second
+	defaultRateLimitSearchMaxRequests = 5
+	defaultRateLimitSearchInterval    = time.Second
+)
+
+// RateLimitState is the rate limit reported by HubSpot in the X-HubSpot-RateLimit-* headers.
+// Remaining is meaningful only when Max is not zero, and DailyRemaining only when Daily is not zero.
+type RateLimitState struct {
+	Max            int
+	Remaining      int
+	Interval       time.Duration
+	Daily          int
+	DailyRemaining int
+}
+
+// parseRateLimitHeader reads the rate limit headers. It returns false if the response has no such headers,
+// which is the case of the search endpoints for example.
+func parseRateLimitHeader(h http.Header) (*RateLimitState, bool) {
+	state := &RateLimitState{}
+	var found bool
+	for name, dst := range map[string]*int{
+		headerRateLimitMax:            &state.Max,
+		headerRateLimitRemaining:      &state.Remaining,
+		headerRateLimitDaily:          &state.Daily,
+		headerRateLimitDailyRemaining: &state.DailyRemaining,
+	} {
+		if v, err := strconv.Atoi(h.Get(name)); err == nil {
+			*dst = v
+			found = true
+		}
+	}
+	if ms, err := strconv.Atoi(h.Get(headerRateLimitInterval)); err == nil {
+		state.Interval = time.Duration(ms) * time.Millisecond
+		found = true
+	}
+	return state, found
+}
+
+// RateLimitConfig configures the budgets of a RateLimiter.
+// Zero values are replaced by the defaults, which are adjusted as soon as HubSpot reports the actual limits.
+type RateLimitConfig struct {
+	// MaxRequests is the number of requests allowed per Interval. The default is 100.
+	MaxRequests int
+	// Interval is the rate limit window. The default is 10 seconds.
+	Interval time.Duration
+	// SearchMaxRequests is the number of search requests allowed per SearchInterval. The default is 5.
+	// The search endpoints do not return the rate limit headers, so this value is never adjusted.
+	SearchMaxRequests int
+	// SearchInterval is the rate limit window of the search endpoints. The default is 1 second.
+	SearchInterval time.Duration
+	// DailyResetLocation is the time zone of the HubSpot account, at whose midnight the daily limit resets. The default is UTC.
+	DailyResetLocation *time.Location
+}
+
+// DailyRateLimitError is returned by RateLimiter.Wait, without sending the request,
+// when HubSpot has reported that the daily limit is exhausted. It matches ErrRateLimited with errors.Is.
+type DailyRateLimitError struct {
+	// ResetAt is when the daily limit resets.
+	ResetAt time.Time
+}
+
+func (e *DailyRateLimitError) Error() string {
+	return fmt.Sprintf("the daily rate limit is exhausted until %s", e.ResetAt.Format(time.RFC3339))
+}
+
+func (e *DailyRateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RateLimiter throttles the requests so that they stay within the HubSpot rate limits.
+// It keeps track of the remaining budget reported by HubSpot, and the search endpoints have a budget of their own.
+// Once HubSpot reports that the daily limit is exhausted, the requests fail with DailyRateLimitError until it resets.
+// HubSpot applies the limits per token, so a RateLimiter should be shared by every Client using the same token.
+// It is safe for concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	api    rateBudget
+	search rateBudget
+	daily  dailyBudget
+	state  RateLimitState
+}
+
+// NewRateLimiter returns a new RateLimiter. The config can be nil to use the defaults.
+func NewRateLimiter(config *RateLimitConfig) *RateLimiter {
+	if config == nil {
+		config = &RateLimitConfig{}
+	}
+	l := &RateLimiter{
+		api:    rateBudget{max: config.MaxRequests, interval: config.Interval},
+		search: rateBudget{max: config.SearchMaxRequests, interval: config.SearchInterval},
+		daily:  dailyBudget{location: config.DailyResetLocation},
+	}
+	if l.daily.location == nil {
+		l.daily.location = time.UTC
+	}
+	if l.api.max <= 0 {
+		l.api.max = defaultRateLimitMaxRequests
+	}
+	if l.api.interval <= 0 {
+		l.api.interval = defaultRateLimitInterval
+	}
+	if l.search.max <= 0 {
+		l.search.max = defaultRateLimitSearchMaxRequests
+	}
+	if l.search.interval <= 0 {
+		l.search.interval = defaultRateLimitSearchInterval
+	}
+	l.api.remaining = l.api.max
+	l.search.remaining = l.search.max
+	return l
+}
+
+// State returns the last rate limit reported by HubSpot.
+func (l *RateLimiter) State() RateLimitState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.state
+}
+
+// Wait blocks until the request fits in the budget or the context is done.
+// It returns a DailyRateLimitError at once when the daily limit is exhausted.
+func (l *RateLimiter) Wait(ctx context.Context, req *http.Request) error {
+	for {
+		l.mu.Lock()
+		now := timeNow()
+		if l.daily.exhausted(now) {
+			resetAt := l.daily.resetAt
+			l.mu.Unlock()
+			return &DailyRateLimitError{ResetAt: resetAt}
+		}
+		wait := l.budget(req).reserve(now)
+		if wait <= 0 {
+			l.daily.take()
+		}
+		l.mu.Unlock()
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Update adjusts the budget with the response of the request.
+func (l *RateLimiter) Update(req *http.Request, resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.budget(req)
+	state, ok := parseRateLimitHeader(resp.Header)
+	if ok {
+		l.state = *state
+		b.adjust(state, timeNow())
+		l.daily.adjust(state, timeNow())
+	}
+	if resp.StatusCode == http.StatusTooManyRequests && (!ok || state.Max == 0) {
+		// The budget is exhausted although HubSpot did not tell how much is left.
+		b.remaining = 0
+	}
+}
+
+func (l *RateLimiter) budget(req *http.Request) *rateBudget {
+	if isSearchRequest(req) {
+		return &l.search
+	}
+	return &l.api
+}
+
+// rateBudget is a fixed window budget of requests.
+type rateBudget struct {
+	max       int
+	remaining int
+	interval  time.Duration
+	resetAt   time.Time
+}
+
+// reserve takes one request from the budget and returns zero, or returns how long to wait if the budget is exhausted.
+func (b *rateBudget) reserve(now time.Time) time.Duration {
+	if b.resetAt.IsZero() || !now.Before(b.resetAt) {
+		b.remaining = b.max
+		b.resetAt = now.Add(b.interval)
+	}
+	if b.remaining <= 0 {
+		return b.resetAt.Sub(now)
+	}
+	b.remaining--
+	return 0
+}
+
+// adjust applies the rate limit reported by HubSpot.
+// The remaining budget is only ever lowered, since other clients may share the same limit.
+func (b *rateBudget) adjust(state *RateLimitState, now time.Time) {
+	if state.Interval > 0 {
+		b.interval = state.Interval
+	}
+	if state.Max <= 0 {
+		return
+	}
+	b.max = state.Max
+	if b.resetAt.IsZero() {
+		b.resetAt = now.Add(b.interval)
+	}
+	if state.Remaining < b.remaining {
+		b.remaining = state.Remaining
+	}
+}
+
+// dailyBudget is the daily limit reported by HubSpot, which resets at midnight in the time zone of the account.
+type dailyBudget struct {
+	location *time.Location
+	// known tells whether HubSpot has reported the remaining budget of the current day.
+	known     bool
+	remaining int
+	resetAt   time.Time
+}
+
+// exhausted reports whether the budget of the day is known to be exhausted.
+func (b *dailyBudget) exhausted(now time.Time) bool {
+	if b.known && !now.Before(b.resetAt) {
+		b.known = false
+	}
+	return b.known && b.remaining <= 0
+}
+
+// take takes one request from the budget.
+func (b *dailyBudget) take() {
+	if b.known && b.remaining > 0 {
+		b.remaining--
+	}
+}
+
+// adjust applies the daily limit reported by HubSpot.
+func (b *dailyBudget) adjust(state *RateLimitState, now time.Time) {
+	if state.Daily <= 0 {
+		return
+	}
+	local := now.In(b.location)
+	b.known = true
+	b.remaining = state.DailyRemaining
+	b.resetAt = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, b.location)
+}
+
+func isSearchRequest(req *http.Request) bool {
+	return req.Method == http.MethodPost && strings.HasSuffix(strings.TrimSuffix(req.URL.Path, "/"), "/search")
+}
@@ -0,0 +1,278 @@
+package hubspot_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRateLimitHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   *hubspot.RateLimitState
+		wantOK bool
+	}{
+		{
+			name: "All headers",
+			header: http.Header{
+				"X-Hubspot-Ratelimit-Max":                   []string{"190"},
+				"X-Hubspot-Ratelimit-Remaining":             []string{"189"},
+				"X-Hubspot-Ratelimit-Interval-Milliseconds": []string{"10000"},
+				"X-Hubspot-Ratelimit-Daily":                 []string{"1000000"},
+				"X-Hubspot-Ratelimit-Daily-Remaining":       []string{"999999"},
+			},
+			want: &hubspot.RateLimitState{
+				Max:            190,
+				Remaining:      189,
+				Interval:       10 * time.Second,
+				Daily:          1000000,
+				DailyRemaining: 999999,
+			},
+			wantOK: true,
+		},
+		{
+			name:   "No headers",
+			header: http.Header{},
+			want:   &hubspot.RateLimitState{},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := hubspot.ExportParseRateLimitHeader(tt.header)
+			if tt.wantOK != ok {
+				t.Errorf("parseRateLimitHeader() ok mismatch: want %t got %t", tt.wantOK, ok)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseRateLimitHeader() result mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func newRateLimitTestRequest(method, path string) *http.Request {
+	return &http.Request{Method: method, URL: &url.URL{Path: path}}
+}
+
+func waitRateLimiter(l *hubspot.RateLimiter, req *http.Request) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	return l.Wait(ctx, req)
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	f := hubspot.MockTimeNow()
+	defer f()
+
+	l := hubspot.NewRateLimiter(&hubspot.RateLimitConfig{MaxRequests: 2, SearchMaxRequests: 1})
+	get := newRateLimitTestRequest(http.MethodGet, "/crm/v3/objects/contacts/1")
+	search := newRateLimitTestRequest(http.MethodPost, "/crm/v3/objects/contacts/search")
+
+	for i := 0; i < 2; i++ {
+		if err := waitRateLimiter(l, get); err != nil {
+			t.Fatalf("Wait() request %d within the budget must not wait: %s", i+1, err)
+		}
+	}
+	if err := waitRateLimiter(l, get); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() must wait when the budget is exhausted: got %v", err)
+	}
+
+	// The search endpoints have a budget of their own.
+	if err := waitRateLimiter(l, search); err != nil {
+		t.Errorf("Wait() search request must not wait: %s", err)
+	}
+	if err := waitRateLimiter(l, search); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() must wait when the search budget is exhausted: got %v", err)
+	}
+}
+
+func TestRateLimiter_Update(t *testing.T) {
+	f := hubspot.MockTimeNow()
+	defer f()
+
+	tests := []struct {
+		name      string
+		resp      *http.Response
+		wantState hubspot.RateLimitState
+		wantErr   error
+	}{
+		{
+			name: "Budget left",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"X-Hubspot-Ratelimit-Max":                   []string{"190"},
+					"X-Hubspot-Ratelimit-Remaining":             []string{"10"},
+					"X-Hubspot-Ratelimit-Interval-Milliseconds": []string{"10000"},
+				},
+			},
+			wantState: hubspot.RateLimitState{Max: 190, Remaining: 10, Interval: 10 * time.Second},
+			wantErr:   nil,
+		},
+		{
+			name: "Budget exhausted by another client",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"X-Hubspot-Ratelimit-Max":                   []string{"190"},
+					"X-Hubspot-Ratelimit-Remaining":             []string{"0"},
+					"X-Hubspot-Ratelimit-Interval-Milliseconds": []string{"10000"},
+				},
+			},
+			wantState: hubspot.RateLimitState{Max: 190, Remaining: 0, Interval: 10 * time.Second},
+			wantErr:   context.DeadlineExceeded,
+		},
+		{
+			name:      "Too many requests without headers",
+			resp:      &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}},
+			wantState: hubspot.RateLimitState{},
+			wantErr:   context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := hubspot.NewRateLimiter(nil)
+			req := newRateLimitTestRequest(http.MethodGet, "/crm/v3/objects/contacts/1")
+			if err := waitRateLimiter(l, req); err != nil {
+				t.Fatalf("Wait() first request must not wait: %s", err)
+			}
+
+			l.Update(req, tt.resp)
+			if diff := cmp.Diff(tt.wantState, l.State()); diff != "" {
+				t.Errorf("State() result mismatch (-want +got):%s", diff)
+			}
+			if err := waitRateLimiter(l, req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Wait() error mismatch: want %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_SharedByClients(t *testing.T) {
+	f := hubspot.MockTimeNow()
+	defer f()
+
+	l := hubspot.NewRateLimiter(nil)
+	httpClient := hubspot.NewMockHTTPClient(&hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{
+			"X-Hubspot-Ratelimit-Max":       []string{"190"},
+			"X-Hubspot-Ratelimit-Remaining": []string{"0"},
+		},
+		Body: []byte(`{}`),
+	})
+	first, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(httpClient), hubspot.WithRateLimiter(l))
+	second, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(httpClient), hubspot.WithRateLimiter(l))
+
+	if err := first.Get("crm/v3/objects/contacts/1", nil, nil); err != nil {
+		t.Fatalf("Get() error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := second.GetWithContext(ctx, "crm/v3/objects/contacts/1", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetWithContext() must be throttled by the shared limiter: got %v", err)
+	}
+}
+
+func TestRateLimiter_Daily(t *testing.T) {
+	now := time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC)
+	f := hubspot.MockTimeNowFunc(func() time.Time { return now })
+	defer f()
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name        string
+		location    *time.Location
+		wantResetAt time.Time
+	}{
+		{
+			name:        "Reset at midnight UTC",
+			wantResetAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "Reset at midnight in the time zone of the account",
+			location:    tokyo,
+			wantResetAt: time.Date(2021, 1, 1, 0, 0, 0, 0, tokyo),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC)
+			l := hubspot.NewRateLimiter(&hubspot.RateLimitConfig{DailyResetLocation: tt.location})
+			req := newRateLimitTestRequest(http.MethodGet, "/crm/v3/objects/contacts/1")
+			if err := waitRateLimiter(l, req); err != nil {
+				t.Fatalf("Wait() first request must not wait: %s", err)
+			}
+			l.Update(req, &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"X-Hubspot-Ratelimit-Daily":           []string{"250000"},
+					"X-Hubspot-Ratelimit-Daily-Remaining": []string{"1"},
+				},
+			})
+
+			// The last request of the day is sent, and the next ones fail at once.
+			if err := waitRateLimiter(l, req); err != nil {
+				t.Fatalf("Wait() last request of the day must not wait: %s", err)
+			}
+			err := waitRateLimiter(l, req)
+			var dailyErr *hubspot.DailyRateLimitError
+			if !errors.As(err, &dailyErr) || !errors.Is(err, hubspot.ErrRateLimited) {
+				t.Fatalf("Wait() error mismatch: want *hubspot.DailyRateLimitError got %v", err)
+			}
+			if !dailyErr.ResetAt.Equal(tt.wantResetAt) {
+				t.Errorf("ResetAt mismatch: want %s got %s", tt.wantResetAt, dailyErr.ResetAt)
+			}
+
+			// The requests are sent again once the daily limit has reset.
+			now = tt.wantResetAt
+			if err := waitRateLimiter(l, req); err != nil {
+				t.Errorf("Wait() must not fail after the reset: %s", err)
+			}
+		})
+	}
+}
+
+func TestClient_DailyRateLimitNotRetried(t *testing.T) {
+	f := hubspot.MockTimeNow()
+	defer f()
+
+	var calls int
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"),
+		hubspot.WithRateLimiter(hubspot.NewRateLimiter(nil)),
+		hubspot.WithRetryConfig(&hubspot.RetryConfig{MaxAttempts: 3}),
+		hubspot.WithHTTPClient(&http.Client{
+			Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+				calls++
+				return hubspot.NewMockHTTPClient(&hubspot.MockConfig{
+					Status: http.StatusOK,
+					Header: http.Header{
+						"X-Hubspot-Ratelimit-Daily":           []string{"250000"},
+						"X-Hubspot-Ratelimit-Daily-Remaining": []string{"0"},
+					},
+					Body: []byte(`{"id":"1"}`),
+				}).Transport.RoundTrip(req)
+			}),
+		}),
+	)
+
+	if _, err := c.CRM.Contact.Get("1", &hubspot.Contact{}, nil); err != nil {
+		t.Fatalf("Get() error: %s", err)
+	}
+	_, err := c.CRM.Contact.Get("1", &hubspot.Contact{}, nil)
+	var dailyErr *hubspot.DailyRateLimitError
+	if !errors.As(err, &dailyErr) {
+		t.Errorf("Get() error mismatch: want *hubspot.DailyRateLimitError got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("requests mismatch: want 1 got %d", calls)
+	}
+}
@@ -0,0 +1,59 @@
+package hubspot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// headerCorrelationID is the header HubSpot uses to identify a request when contacting its support.
+const headerCorrelationID = "X-HubSpot-Correlation-Id"
+
+// Response is the metadata of a response returned by HubSpot.
+// Use CaptureResponse to retrieve it from any call, whether it succeeds or not.
+type Response struct {
+	// StatusCode is the HTTP status code. It is zero if no response was received.
+	StatusCode int
+	// Header is the raw response header.
+	Header http.Header
+	// RateLimit is the rate limit reported by HubSpot. It is nil if the response has no rate limit headers.
+	RateLimit *RateLimitState
+	// CorrelationID identifies the request in HubSpot. Report it when contacting the HubSpot support.
+	CorrelationID string
+	// Attempts is the number of attempts made, which is more than one when the request has been retried.
+	Attempts int
+}
+
+type responseCaptureKey struct{}
+
+// CaptureResponse returns a context that makes the call store the metadata of its response into resp.
+// If the context is used for several calls, resp holds the metadata of the last one.
+//
+//	var res hubspot.Response
+//	_, err := client.CRM.Contact.GetWithContext(hubspot.CaptureResponse(ctx, &res), "id", &hubspot.Contact{}, nil)
+//	log.Println(res.CorrelationID)
+func CaptureResponse(ctx context.Context, resp *Response) context.Context {
+	return context.WithValue(ctx, responseCaptureKey{}, resp)
+}
+
+// captureResponse stores the metadata into the Response registered with CaptureResponse, if any.
+func captureResponse(ctx context.Context, resp *http.Response, attempts int, err error) {
+	capture, ok := ctx.Value(responseCaptureKey{}).(*Response)
+	if !ok || capture == nil {
+		return
+	}
+
+	*capture = Response{Attempts: attempts}
+	if resp != nil {
+		capture.StatusCode = resp.StatusCode
+		capture.Header = resp.Header
+		capture.CorrelationID = resp.Header.Get(headerCorrelationID)
+		if state, ok := parseRateLimitHeader(resp.Header); ok {
+			capture.RateLimit = state
+		}
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && capture.CorrelationID == "" {
+		capture.CorrelationID = apiErr.CorrelationID
+	}
+}
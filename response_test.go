@@ -0,0 +1,103 @@
+package hubspot_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCaptureResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		conf *hubspot.MockConfig
+		want hubspot.Response
+	}{
+		{
+			name: "Capture successful response",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusOK,
+				Header: http.Header{
+					"X-Hubspot-Correlation-Id":                  []string{"aeb5f871-7f07-4993-9211-075dc63e7cbf"},
+					"X-Hubspot-Ratelimit-Max":                   []string{"190"},
+					"X-Hubspot-Ratelimit-Remaining":             []string{"189"},
+					"X-Hubspot-Ratelimit-Interval-Milliseconds": []string{"10000"},
+				},
+				Body: []byte(`{"id":"001"}`),
+			},
+			want: hubspot.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"X-Hubspot-Correlation-Id":                  []string{"aeb5f871-7f07-4993-9211-075dc63e7cbf"},
+					"X-Hubspot-Ratelimit-Max":                   []string{"190"},
+					"X-Hubspot-Ratelimit-Remaining":             []string{"189"},
+					"X-Hubspot-Ratelimit-Interval-Milliseconds": []string{"10000"},
+				},
+				RateLimit: &hubspot.RateLimitState{
+					Max:       190,
+					Remaining: 189,
+					Interval:  10 * time.Second,
+				},
+				CorrelationID: "aeb5f871-7f07-4993-9211-075dc63e7cbf",
+				Attempts:      1,
+			},
+		},
+		{
+			name: "Capture error response",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusNotFound,
+				Header: http.Header{},
+				Body:   []byte(`{"status":"error","message":"resource not found","correlationId":"b2c3d4e5-7f07-4993-9211-075dc63e7cbf"}`),
+			},
+			want: hubspot.Response{
+				StatusCode:    http.StatusNotFound,
+				Header:        http.Header{},
+				CorrelationID: "b2c3d4e5-7f07-4993-9211-075dc63e7cbf",
+				Attempts:      1,
+			},
+		},
+		{
+			name: "Capture missing scopes response",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusForbidden,
+				Header: http.Header{},
+				Body:   []byte(`{"status":"error","message":"This app hasn't been granted all required scopes to make this call.","correlationId":"c3d4e5f6-7f07-4993-9211-075dc63e7cbf","category":"MISSING_SCOPES"}`),
+			},
+			want: hubspot.Response{
+				StatusCode:    http.StatusForbidden,
+				Header:        http.Header{},
+				CorrelationID: "c3d4e5f6-7f07-4993-9211-075dc63e7cbf",
+				Attempts:      1,
+			},
+		},
+		{
+			name: "Capture conflict response",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusConflict,
+				Header: http.Header{},
+				Body:   []byte(`{"status":"error","message":"Contact already exists. Existing ID: 512","correlationId":"d4e5f6a7-7f07-4993-9211-075dc63e7cbf","category":"CONFLICT"}`),
+			},
+			want: hubspot.Response{
+				StatusCode:    http.StatusConflict,
+				Header:        http.Header{},
+				CorrelationID: "d4e5f6a7-7f07-4993-9211-075dc63e7cbf",
+				Attempts:      1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := hubspot.NewMockClient(tt.conf)
+
+			var got hubspot.Response
+			ctx := hubspot.CaptureResponse(context.Background(), &got)
+			_, _ = c.CRM.Contact.GetWithContext(ctx, "001", &hubspot.Contact{}, nil)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CaptureResponse() result mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
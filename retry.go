@@ -0,0 +1,218 @@
+package hubspot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff     = 30 * time.Second
+)
+
+// RetryConfig configures the automatic retries of requests.
+// Idempotent requests are retried when HubSpot responds with 429, 502, 503 or 504, or when a network error occurs.
+// The wait between attempts grows exponentially with jitter, unless HubSpot specifies it with the Retry-After header.
+// A request is not retried when the Retry-After header asks for a longer wait than MaxBackoff.
+// Zero values are replaced by the defaults.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts including the first one. The default is 3.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. The default is 500ms.
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper bound of the exponential backoff and of the Retry-After header. The default is 30s.
+	MaxBackoff time.Duration
+	// RetryPOST reports whether the given POST request can be retried.
+	// POST requests are not idempotent, so they are never retried when it is nil.
+	// Use RetrySearchAndBatch to opt the search and batch endpoints in.
+	RetryPOST func(req *http.Request) bool
+}
+
+// RetrySearchAndBatch reports whether the request is sent to a search or batch endpoint.
+// It can be set to RetryConfig.RetryPOST.
+func RetrySearchAndBatch(req *http.Request) bool {
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	return strings.HasSuffix(path, "/search") || strings.Contains(path, "/batch/")
+}
+
+// RetryError is returned when a request still fails after it has been retried.
+// The error of the last attempt can be retrieved with errors.Unwrap, errors.Is or errors.As.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %s", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+func (rc *RetryConfig) maxAttempts() int {
+	if rc.MaxAttempts <= 0 {
+		return defaultRetryMaxAttempts
+	}
+	return rc.MaxAttempts
+}
+
+func (rc *RetryConfig) initialBackoff() time.Duration {
+	if rc.InitialBackoff <= 0 {
+		return defaultRetryInitialBackoff
+	}
+	return rc.InitialBackoff
+}
+
+func (rc *RetryConfig) maxBackoff() time.Duration {
+	if rc.MaxBackoff <= 0 {
+		return defaultRetryMaxBackoff
+	}
+	return rc.MaxBackoff
+}
+
+// retryable reports whether the request may be sent more than once.
+func (rc *RetryConfig) retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body cannot be replayed.
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return rc.RetryPOST != nil && rc.RetryPOST(req)
+	}
+	return false
+}
+
+// shouldRetry reports whether the result of an attempt is worth retrying.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		var dailyErr *DailyRateLimitError
+		if errors.As(err, &dailyErr) {
+			// The request has not been sent, and would not be until the next day.
+			return false
+		}
+		// Do not retry when the caller has given up.
+		return req.Context().Err() == nil
+	}
+	return isRetryableStatusCode(resp.StatusCode)
+}
+
+// backoff returns the wait before the next attempt, and false when the Retry-After header exceeds MaxBackoff.
+// attempt is the number of attempts made so far.
+func (rc *RetryConfig) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header); ok {
+			return d, d <= rc.maxBackoff()
+		}
+	}
+	d := rc.initialBackoff()
+	for i := 1; i < attempt && d < rc.maxBackoff(); i++ {
+		d *= 2
+	}
+	if d > rc.maxBackoff() {
+		d = rc.maxBackoff()
+	}
+	return jitter(d), true
+}
+
+// retryAfter parses the Retry-After header, which is either delay seconds or an HTTP date.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := strings.TrimSpace(h.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+	if sec, err := strconv.Atoi(v); err == nil {
+		if sec < 0 {
+			return 0, false
+		}
+		return time.Duration(sec) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(timeNow())
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// jitter is defaultJitter, but it has been redefined as a test variable.
+var jitter = defaultJitter
+
+// defaultJitter returns a random duration between d/2 and d, so that clients retrying at the same time spread out.
+func defaultJitter(d time.Duration) time.Duration {
+	half := int64(d / 2)
+	if half <= 0 {
+		return d
+	}
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return time.Duration(half + jitterRand.Int63n(half+1))
+}
+
+// doWithRetry sends the request and retries it according to the retry configuration.
+// It returns the last response or error along with the number of attempts made.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, int, error) {
+	rc := c.retryConfig
+	if rc == nil || !rc.retryable(req) {
+		resp, err := c.send(req, 1)
+		return resp, 1, err
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		r := req
+		if attempt > 1 {
+			r = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, attempt - 1, err
+				}
+				r.Body = body
+			}
+		}
+
+		resp, err := c.send(r, attempt)
+		if attempt >= rc.maxAttempts() || !shouldRetry(r, resp, err) {
+			return resp, attempt, err
+		}
+
+		wait, ok := rc.backoff(attempt, resp)
+		if !ok {
+			// Waiting that long would exceed the budget of the caller.
+			return resp, attempt, err
+		}
+		if resp != nil {
+			// Drain the body so that the connection can be reused.
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
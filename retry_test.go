@@ -0,0 +1,277 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+type retryTestResponse struct {
+	status int
+	header http.Header
+	err    error
+}
+
+func TestClient_Retry(t *testing.T) {
+	f := hubspot.MockJitter()
+	defer f()
+
+	type args struct {
+		method string
+		path   string
+		data   interface{}
+	}
+	tests := []struct {
+		name      string
+		config    *hubspot.RetryConfig
+		responses []retryTestResponse
+		args      args
+		wantCalls int
+		wantBody  []string
+		wantErr   error
+	}{
+		{
+			name:      "Succeed after retrying 503",
+			config:    &hubspot.RetryConfig{InitialBackoff: time.Millisecond},
+			responses: []retryTestResponse{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			args:      args{method: http.MethodGet, path: "objects/test"},
+			wantCalls: 2,
+			wantErr:   nil,
+		},
+		{
+			name:      "Succeed after retrying network error",
+			config:    &hubspot.RetryConfig{InitialBackoff: time.Millisecond},
+			responses: []retryTestResponse{{err: errors.New("connection reset")}, {status: http.StatusOK}},
+			args:      args{method: http.MethodDelete, path: "objects/test"},
+			wantCalls: 2,
+			wantErr:   nil,
+		},
+		{
+			name:   "Give up after max attempts",
+			config: &hubspot.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+			responses: []retryTestResponse{
+				{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{"0"}}},
+				{status: http.StatusBadGateway},
+				{status: http.StatusGatewayTimeout},
+			},
+			args:      args{method: http.MethodGet, path: "objects/test"},
+			wantCalls: 3,
+			wantErr: &hubspot.RetryError{
+				Attempts: 3,
+				Err:      &hubspot.APIError{HTTPStatusCode: http.StatusGatewayTimeout},
+			},
+		},
+		{
+			name:   "Give up when Retry-After exceeds MaxBackoff",
+			config: &hubspot.RetryConfig{InitialBackoff: time.Millisecond, MaxBackoff: time.Second},
+			responses: []retryTestResponse{
+				{status: http.StatusServiceUnavailable},
+				{status: http.StatusServiceUnavailable, header: http.Header{"Retry-After": []string{"60"}}},
+				{status: http.StatusOK},
+			},
+			args:      args{method: http.MethodGet, path: "objects/test"},
+			wantCalls: 2,
+			wantErr: &hubspot.RetryError{
+				Attempts: 2,
+				Err:      &hubspot.APIError{HTTPStatusCode: http.StatusServiceUnavailable, RetryAfter: time.Minute},
+			},
+		},
+		{
+			name:   "Do not retry when the first Retry-After exceeds MaxBackoff",
+			config: &hubspot.RetryConfig{InitialBackoff: time.Millisecond, MaxBackoff: time.Second},
+			responses: []retryTestResponse{
+				{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{"60"}}},
+				{status: http.StatusOK},
+			},
+			args:      args{method: http.MethodGet, path: "objects/test"},
+			wantCalls: 1,
+			wantErr:   &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute},
+		},
+		{
+			name:      "Do not retry client error",
+			config:    &hubspot.RetryConfig{InitialBackoff: time.Millisecond},
+			responses: []retryTestResponse{{status: http.StatusBadRequest}, {status: http.StatusOK}},
+			args:      args{method: http.MethodGet, path: "objects/test"},
+			wantCalls: 1,
+			wantErr:   &hubspot.APIError{HTTPStatusCode: http.StatusBadRequest},
+		},
+		{
+			name:      "Do not retry POST by default",
+			config:    &hubspot.RetryConfig{InitialBackoff: time.Millisecond},
+			responses: []retryTestResponse{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			args:      args{method: http.MethodPost, path: "objects/test/search", data: map[string]string{"query": "a"}},
+			wantCalls: 1,
+			wantBody:  []string{`{"query":"a"}`},
+			wantErr:   &hubspot.APIError{HTTPStatusCode: http.StatusServiceUnavailable},
+		},
+		{
+			name:      "Retry opted-in POST and replay the body",
+			config:    &hubspot.RetryConfig{InitialBackoff: time.Millisecond, RetryPOST: hubspot.RetrySearchAndBatch},
+			responses: []retryTestResponse{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			args:      args{method: http.MethodPost, path: "objects/test/search", data: map[string]string{"query": "a"}},
+			wantCalls: 2,
+			wantBody:  []string{`{"query":"a"}`, `{"query":"a"}`},
+			wantErr:   nil,
+		},
+		{
+			name:      "Do not retry opted-in POST to another endpoint",
+			config:    &hubspot.RetryConfig{InitialBackoff: time.Millisecond, RetryPOST: hubspot.RetrySearchAndBatch},
+			responses: []retryTestResponse{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			args:      args{method: http.MethodPost, path: "objects/test", data: map[string]string{"query": "a"}},
+			wantCalls: 1,
+			wantBody:  []string{`{"query":"a"}`},
+			wantErr:   &hubspot.APIError{HTTPStatusCode: http.StatusServiceUnavailable},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				calls  int
+				bodies []string
+			)
+			c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"),
+				hubspot.WithRetryConfig(tt.config),
+				hubspot.WithHTTPClient(&http.Client{
+					Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+						res := tt.responses[calls]
+						calls++
+						if req.Body != nil && req.Body != http.NoBody {
+							b, _ := ioutil.ReadAll(req.Body)
+							bodies = append(bodies, string(b))
+						}
+						if res.err != nil {
+							return nil, res.err
+						}
+						return &http.Response{
+							StatusCode: res.status,
+							Header:     res.header,
+							Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+						}, nil
+					}),
+				}),
+			)
+
+			err := c.CreateAndDo(tt.args.method, tt.args.path, hubspot.MIMETypeJSON, tt.args.data, nil, nil)
+			if diff := cmp.Diff(tt.wantErr, err); diff != "" {
+				t.Errorf("CreateAndDo() error mismatch (-want +got):%s", diff)
+			}
+			if tt.wantCalls != calls {
+				t.Errorf("CreateAndDo() calls mismatch: want %d got %d", tt.wantCalls, calls)
+			}
+			if diff := cmp.Diff(tt.wantBody, bodies); diff != "" {
+				t.Errorf("CreateAndDo() body mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	f := hubspot.MockTimeNow()
+	defer f()
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:   "Delay seconds",
+			header: http.Header{"Retry-After": []string{"10"}},
+			want:   10 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "HTTP date",
+			header: http.Header{"Retry-After": []string{"Thu, 31 Dec 2020 12:00:30 GMT"}},
+			want:   30 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "Missing header",
+			header: http.Header{},
+			want:   0,
+			wantOK: false,
+		},
+		{
+			name:   "Invalid header",
+			header: http.Header{"Retry-After": []string{"soon"}},
+			want:   0,
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := hubspot.ExportRetryAfter(tt.header)
+			if tt.want != got || tt.wantOK != ok {
+				t.Errorf("retryAfter() result mismatch: want %s, %t got %s, %t", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestRetryConfig_backoff(t *testing.T) {
+	f := hubspot.MockJitter()
+	defer f()
+
+	rc := &hubspot.RetryConfig{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	tests := []struct {
+		name    string
+		attempt int
+		resp    *http.Response
+		want    time.Duration
+		wantOK  bool
+	}{
+		{name: "First retry", attempt: 1, want: time.Second, wantOK: true},
+		{name: "Second retry", attempt: 2, want: 2 * time.Second, wantOK: true},
+		{name: "Capped by MaxBackoff", attempt: 5, want: 5 * time.Second, wantOK: true},
+		{
+			name:    "Retry-After takes precedence",
+			attempt: 1,
+			resp:    &http.Response{Header: http.Header{"Retry-After": []string{"4"}}},
+			want:    4 * time.Second,
+			wantOK:  true,
+		},
+		{
+			name:    "Retry-After exceeding MaxBackoff",
+			attempt: 1,
+			resp:    &http.Response{Header: http.Header{"Retry-After": []string{"8"}}},
+			want:    8 * time.Second,
+			wantOK:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := hubspot.ExportBackoff(rc, tt.attempt, tt.resp)
+			if tt.want != got || tt.wantOK != ok {
+				t.Errorf("backoff() result mismatch: want %s, %t got %s, %t", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestRetrySearchAndBatch(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "/crm/v3/objects/contacts/search", want: true},
+		{path: "/crm/v3/objects/contacts/batch/read", want: true},
+		{path: "/crm/v3/objects/contacts", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := &http.Request{Method: http.MethodPost, URL: &url.URL{Path: tt.path}}
+			if got := hubspot.RetrySearchAndBatch(req); tt.want != got {
+				t.Errorf("RetrySearchAndBatch() result mismatch: want %t got %t", tt.want, got)
+			}
+		})
+	}
+}
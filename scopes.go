@@ -0,0 +1,352 @@
+package hubspot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	privateAppTokenInfoPath = "oauth/v2/private-apps/get/access-token-info"
+)
+
+// OperationScopes maps the operations of the services to the OAuth scopes they require.
+// It is used by Client.CheckScopes and to name the missing scopes when HubSpot responds with 403.
+// Operations of your own can be added before the clients are used.
+// Reference: https://developers.hubspot.com/docs/api/working-with-oauth#scopes
+var OperationScopes = map[string][]string{
+	"CRM.Contact.Get":                 {"crm.objects.contacts.read"},
+	"CRM.Contact.Create":              {"crm.objects.contacts.write"},
+	"CRM.Contact.CreateOrGet":         {"crm.objects.contacts.read", "crm.objects.contacts.write"},
+	"CRM.Contact.Update":              {"crm.objects.contacts.write"},
+	"CRM.Contact.Delete":              {"crm.objects.contacts.write"},
+	"CRM.Contact.AssociateAnotherObj": {"crm.objects.contacts.write"},
+	"CRM.Contact.Search":              {"crm.objects.contacts.read"},
+	"CRM.Contact.SearchByEmail":       {"crm.objects.contacts.read"},
+	"CRM.Contact.BatchRead":           {"crm.objects.contacts.read"},
+	"CRM.Contact.BatchCreate":         {"crm.objects.contacts.write"},
+	"CRM.Contact.BatchUpdate":         {"crm.objects.contacts.write"},
+	"CRM.Contact.BatchUpsert":         {"crm.objects.contacts.write"},
+	"CRM.Contact.BatchArchive":        {"crm.objects.contacts.write"},
+
+	"CRM.Company.Get":                 {"crm.objects.companies.read"},
+	"CRM.Company.Create":              {"crm.objects.companies.write"},
+	"CRM.Company.CreateOrGet":         {"crm.objects.companies.read", "crm.objects.companies.write"},
+	"CRM.Company.Update":              {"crm.objects.companies.write"},
+	"CRM.Company.Delete":              {"crm.objects.companies.write"},
+	"CRM.Company.AssociateAnotherObj": {"crm.objects.companies.write"},
+	"CRM.Company.Search":              {"crm.objects.companies.read"},
+	"CRM.Company.SearchByDomain":      {"crm.objects.companies.read"},
+	"CRM.Company.SearchByName":        {"crm.objects.companies.read"},
+	"CRM.Company.BatchRead":           {"crm.objects.companies.read"},
+	"CRM.Company.BatchCreate":         {"crm.objects.companies.write"},
+	"CRM.Company.BatchUpdate":         {"crm.objects.companies.write"},
+	"CRM.Company.BatchUpsert":         {"crm.objects.companies.write"},
+	"CRM.Company.BatchArchive":        {"crm.objects.companies.write"},
+
+	"CRM.Deal.Get":                 {"crm.objects.deals.read"},
+	"CRM.Deal.Create":              {"crm.objects.deals.write"},
+	"CRM.Deal.Update":              {"crm.objects.deals.write"},
+	"CRM.Deal.AssociateAnotherObj": {"crm.objects.deals.write"},
+	"CRM.Deal.Search":              {"crm.objects.deals.read"},
+	"CRM.Deal.SearchByName":        {"crm.objects.deals.read"},
+	"CRM.Deal.BatchRead":           {"crm.objects.deals.read"},
+	"CRM.Deal.BatchCreate":         {"crm.objects.deals.write"},
+	"CRM.Deal.BatchUpdate":         {"crm.objects.deals.write"},
+	"CRM.Deal.BatchUpsert":         {"crm.objects.deals.write"},
+	"CRM.Deal.BatchArchive":        {"crm.objects.deals.write"},
+
+	"CRM.Note.Get":                 {"crm.objects.contacts.read"},
+	"CRM.Note.Create":              {"crm.objects.contacts.write"},
+	"CRM.Note.Update":              {"crm.objects.contacts.write"},
+	"CRM.Note.Delete":              {"crm.objects.contacts.write"},
+	"CRM.Note.AssociateAnotherObj": {"crm.objects.contacts.write"},
+
+	"CRM.Imports.Active": {"crm.import"},
+	"CRM.Imports.Get":    {"crm.import"},
+	"CRM.Imports.Cancel": {"crm.import"},
+	"CRM.Imports.Errors": {"crm.import"},
+	"CRM.Imports.Start":  {"crm.import"},
+
+	"CRM.Schemas.List":   {"crm.schemas.custom.read"},
+	"CRM.Schemas.Get":    {"crm.schemas.custom.read"},
+	"CRM.Schemas.Create": {"crm.schemas.custom.write"},
+	"CRM.Schemas.Update": {"crm.schemas.custom.write"},
+	"CRM.Schemas.Delete": {"crm.schemas.custom.write"},
+
+	"CRM.Tickets.List":    {"tickets"},
+	"CRM.Tickets.Get":     {"tickets"},
+	"CRM.Tickets.Create":  {"tickets"},
+	"CRM.Tickets.Update":  {"tickets"},
+	"CRM.Tickets.Archive": {"tickets"},
+	"CRM.Tickets.Search":  {"tickets"},
+
+	"Marketing.Email.GetStatistics":           {"content"},
+	"Marketing.Email.ListStatistics":          {"content"},
+	"Marketing.Transactional.SendSingleEmail": {"transactional-email"},
+
+	"Conversation.VisitorIdentification.GenerateIdentificationToken": {"conversations.visitor_identification.tokens.create"},
+}
+
+// objectTypeOperations are the operations whose scopes depend on the object type they are called with.
+// Their scopes are crm.<kind>.<object type>.<access>, or crm.<kind>.custom.<access> for the custom objects.
+var objectTypeOperations = map[string]struct{ kind, access string }{
+	"CRM.Objects.Get":                 {"objects", "read"},
+	"CRM.Objects.List":                {"objects", "read"},
+	"CRM.Objects.Search":              {"objects", "read"},
+	"CRM.Objects.BatchRead":           {"objects", "read"},
+	"CRM.Objects.Create":              {"objects", "write"},
+	"CRM.Objects.Update":              {"objects", "write"},
+	"CRM.Objects.Archive":             {"objects", "write"},
+	"CRM.Objects.AssociateAnotherObj": {"objects", "write"},
+	"CRM.Objects.BatchCreate":         {"objects", "write"},
+	"CRM.Objects.BatchUpdate":         {"objects", "write"},
+	"CRM.Objects.BatchUpsert":         {"objects", "write"},
+	"CRM.Objects.BatchArchive":        {"objects", "write"},
+
+	"CRM.Properties.List":   {"schemas", "read"},
+	"CRM.Properties.Get":    {"schemas", "read"},
+	"CRM.Properties.Create": {"schemas", "write"},
+	"CRM.Properties.Update": {"schemas", "write"},
+	"CRM.Properties.Delete": {"schemas", "write"},
+}
+
+// standardObjectTypeIDs maps the type IDs of the standard objects to the names used in the scopes.
+var standardObjectTypeIDs = map[string]ObjectType{
+	"0-1": ObjectTypeContact,
+	"0-2": "companies",
+	"0-3": ObjectTypeDeal,
+	"0-5": ObjectTypeTicket,
+	"0-7": ObjectTypeProduct,
+	"0-8": ObjectTypeLineItem,
+}
+
+// fullyQualifiedObjectName matches the fully qualified names of the custom objects, e.g. p12345_cars.
+var fullyQualifiedObjectName = regexp.MustCompile(`^p\d+_`)
+
+// operationScopes returns the scopes required by an operation called with an object type.
+func operationScopes(name, objectType string) ([]string, bool) {
+	if scopes, ok := OperationScopes[name]; ok {
+		return scopes, true
+	}
+	o, ok := objectTypeOperations[name]
+	if !ok || objectType == "" {
+		return nil, false
+	}
+	if strings.HasPrefix(objectType, "2-") || fullyQualifiedObjectName.MatchString(objectType) {
+		return []string{fmt.Sprintf("crm.%s.custom.%s", o.kind, o.access)}, true
+	}
+	if t, ok := standardObjectTypes[ObjectType(objectType)]; ok {
+		objectType = string(t)
+	} else if t, ok := standardObjectTypeIDs[objectType]; ok {
+		objectType = string(t)
+	}
+	switch objectType {
+	case string(ObjectTypeTicket):
+		return []string{"tickets"}, true
+	case string(ObjectTypeNote):
+		// The notes are engagements, which the scopes of the contacts cover.
+		objectType = string(ObjectTypeContact)
+	}
+	return []string{fmt.Sprintf("crm.%s.%s.%s", o.kind, objectType, o.access)}, true
+}
+
+// ScopeError tells the OAuth scopes which have not been granted to call some operations.
+// It is returned by Client.CheckScopes, and retrieved with errors.As from the APIError returned
+// when HubSpot responds with 403 because of missing scopes.
+type ScopeError struct {
+	// Operations are the operations which cannot be called, when known.
+	Operations []string
+	// MissingScopes are the scopes named by HubSpot, or else the scopes required by the operations.
+	MissingScopes []string
+	// APIError is the error returned by HubSpot. It is nil when the error is returned by CheckScopes.
+	APIError *APIError
+}
+
+func (e *ScopeError) Error() string {
+	if len(e.MissingScopes) == 0 && e.APIError != nil {
+		return e.APIError.Error()
+	}
+	msg := "missing scopes"
+	if len(e.Operations) > 0 {
+		msg += " for " + strings.Join(e.Operations, ", ")
+	}
+	msg += ": " + strings.Join(e.MissingScopes, ", ")
+	if e.APIError != nil {
+		return fmt.Sprintf("%d: %s", e.APIError.HTTPStatusCode, msg)
+	}
+	return msg
+}
+
+func (e *ScopeError) Is(target error) bool {
+	return target == ErrMissingScopes
+}
+
+func (e *ScopeError) Unwrap() error {
+	if e.APIError == nil {
+		return nil
+	}
+	return e.APIError
+}
+
+// newScopeError returns the ScopeError of a 403 response caused by missing scopes.
+func newScopeError(apiErr *APIError) *ScopeError {
+	e := &ScopeError{
+		MissingScopes: uniqueScopes(apiErr.Context.RequiredScopes),
+		APIError:      apiErr,
+	}
+	if apiErr.Operation != "" {
+		e.Operations = []string{apiErr.Operation}
+	}
+	return e
+}
+
+// requiredScopes returns the scopes listed in the body of a 403 response caused by missing scopes.
+func requiredScopes(body []byte) []string {
+	var b struct {
+		Context struct {
+			RequiredScopes []string `json:"requiredScopes"`
+		} `json:"context"`
+		Errors []struct {
+			Context struct {
+				RequiredScopes []string `json:"requiredScopes"`
+			} `json:"context"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &b); err != nil {
+		return nil
+	}
+	scopes := b.Context.RequiredScopes
+	for _, detail := range b.Errors {
+		scopes = append(scopes, detail.Context.RequiredScopes...)
+	}
+	return uniqueScopes(scopes)
+}
+
+// withOperationScopes completes the APIError of missing scopes with the operation of the context and the scopes it requires.
+func withOperationScopes(ctx context.Context, err error) error {
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Category != MissingScopesError {
+		return err
+	}
+	op, ok := ctx.Value(operationKey{}).(operation)
+	if !ok {
+		return err
+	}
+	apiErr.Operation = op.name
+	if len(apiErr.Context.RequiredScopes) == 0 {
+		scopes, _ := operationScopes(op.name, op.objectType)
+		apiErr.Context.RequiredScopes = append([]string(nil), scopes...)
+	}
+	return apiErr
+}
+
+// CheckScopes checks that the scopes required by the operations, e.g. CRM.Contact.Create,
+// have been granted to the token of the client. It returns a ScopeError naming the missing scopes.
+// The operations whose scopes depend on the object type are given with it after a slash, e.g. CRM.Properties.Get/contacts.
+// Call it at startup to fail early rather than in the middle of a sync.
+// It is supported with OAuth and private app tokens.
+func (c *Client) CheckScopes(ctx context.Context, operations ...string) error {
+	var required []string
+	opScopes := make([][]string, 0, len(operations))
+	for _, op := range operations {
+		name, objectType := op, ""
+		if i := strings.Index(op, "/"); i >= 0 {
+			name, objectType = op[:i], op[i+1:]
+		}
+		scopes, ok := operationScopes(name, objectType)
+		if !ok {
+			if _, typed := objectTypeOperations[name]; typed && objectType == "" {
+				return fmt.Errorf("operation %s needs an object type, e.g. %s/contacts", op, op)
+			}
+			return fmt.Errorf("unknown operation %s", op)
+		}
+		required = append(required, scopes...)
+		opScopes = append(opScopes, scopes)
+	}
+
+	sp, ok := c.authenticator.(scopeProvider)
+	if !ok {
+		return errors.New("the scopes can be checked only with OAuth or a private app token")
+	}
+	granted, err := sp.grantedScopes(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	missing := missingScopes(granted, uniqueScopes(required))
+	if len(missing) == 0 {
+		return nil
+	}
+	scopeErr := &ScopeError{MissingScopes: missing}
+	for i, op := range operations {
+		if len(missingScopes(granted, opScopes[i])) > 0 {
+			scopeErr.Operations = append(scopeErr.Operations, op)
+		}
+	}
+	return scopeErr
+}
+
+// scopeProvider is implemented by the authenticators which can tell the scopes granted to their token.
+type scopeProvider interface {
+	grantedScopes(ctx context.Context, c *Client) ([]string, error)
+}
+
+func (o *OAuth) grantedScopes(ctx context.Context, c *Client) ([]string, error) {
+	t, err := o.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info, err := c.OAuth.GetAccessTokenWithContext(ctx, t.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	return info.Scopes, nil
+}
+
+func (p *PrivateAppToken) grantedScopes(ctx context.Context, c *Client) ([]string, error) {
+	return privateAppTokenScopes(ctx, c, p.accessToken)
+}
+
+func (p *PrivateAppTokenSource) grantedScopes(ctx context.Context, c *Client) ([]string, error) {
+	token, err := p.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return privateAppTokenScopes(ctx, c, token)
+}
+
+// privateAppTokenScopes returns the scopes granted to a private app token.
+func privateAppTokenScopes(ctx context.Context, c *Client, token string) ([]string, error) {
+	req := struct {
+		TokenKey string `json:"tokenKey"`
+	}{TokenKey: token}
+	var info struct {
+		Scopes []string `json:"scopes"`
+	}
+	if err := c.PostWithContext(ctx, privateAppTokenInfoPath, req, &info); err != nil {
+		return nil, err
+	}
+	return info.Scopes, nil
+}
+
+// uniqueScopes returns the sorted scopes without duplicates.
+func uniqueScopes(scopes []string) []string {
+	if len(scopes) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(scopes))
+	var unique []string
+	for _, s := range scopes {
+		if !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,186 @@
+package hubspot_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot"
+)
+
+func TestScopeError_MissingScopesResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr *hubspot.ScopeError
+		wantMsg string
+	}{
+		{
+			name: "Scopes named by HubSpot",
+			body: `{"status":"error","message":"This app hasn't been granted all required scopes to make this call.","errors":[{"message":"One or more of the following scopes are required.","context":{"requiredScopes":["crm.objects.contacts.write"]}}],"category":"MISSING_SCOPES"}`,
+			wantErr: &hubspot.ScopeError{
+				Operations:    []string{"CRM.Contact.Create"},
+				MissingScopes: []string{"crm.objects.contacts.write"},
+				APIError: &hubspot.APIError{
+					HTTPStatusCode: http.StatusForbidden,
+					Status:         "error",
+					Message:        "This app hasn't been granted all required scopes to make this call.",
+					Context:        hubspot.ErrContext{RequiredScopes: []string{"crm.objects.contacts.write"}},
+					Category:       hubspot.MissingScopesError,
+					Operation:      "CRM.Contact.Create",
+				},
+			},
+			wantMsg: "403: missing scopes for CRM.Contact.Create: crm.objects.contacts.write",
+		},
+		{
+			name: "Scopes of the operation",
+			body: `{"status":"error","message":"This app hasn't been granted all required scopes to make this call.","category":"MISSING_SCOPES"}`,
+			wantErr: &hubspot.ScopeError{
+				Operations:    []string{"CRM.Contact.Create"},
+				MissingScopes: []string{"crm.objects.contacts.write"},
+				APIError: &hubspot.APIError{
+					HTTPStatusCode: http.StatusForbidden,
+					Status:         "error",
+					Message:        "This app hasn't been granted all required scopes to make this call.",
+					Context:        hubspot.ErrContext{RequiredScopes: []string{"crm.objects.contacts.write"}},
+					Category:       hubspot.MissingScopesError,
+					Operation:      "CRM.Contact.Create",
+				},
+			},
+			wantMsg: "403: missing scopes for CRM.Contact.Create: crm.objects.contacts.write",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := hubspot.NewMockClient(&hubspot.MockConfig{
+				Status: http.StatusForbidden,
+				Body:   []byte(tt.body),
+			})
+
+			_, err := c.CRM.Contact.Create(&hubspot.Contact{})
+			if _, ok := err.(*hubspot.APIError); !ok {
+				t.Fatalf("Create() error is not an APIError: %#v", err)
+			}
+			var scopeErr *hubspot.ScopeError
+			if !errors.As(err, &scopeErr) {
+				t.Fatalf("Create() error is not a ScopeError: %#v", err)
+			}
+			if !reflect.DeepEqual(tt.wantErr, scopeErr) {
+				t.Errorf("Create() error mismatch: want %#v got %#v", tt.wantErr, scopeErr)
+				return
+			}
+			if scopeErr.Error() != tt.wantMsg {
+				t.Errorf("Error() mismatch: want %s got %s", tt.wantMsg, scopeErr.Error())
+			}
+			if !errors.Is(err, hubspot.ErrMissingScopes) || !errors.Is(err, hubspot.ErrForbidden) {
+				t.Errorf("errors.Is() must match ErrMissingScopes and ErrForbidden: %s", err)
+			}
+		})
+	}
+}
+
+func TestScopeError_ObjectTypeScopes(t *testing.T) {
+	c := hubspot.NewMockClient(&hubspot.MockConfig{
+		Status: http.StatusForbidden,
+		Body:   []byte(`{"status":"error","message":"This app hasn't been granted all required scopes to make this call.","category":"MISSING_SCOPES"}`),
+	})
+
+	_, err := c.CRM.Properties.Get("p12345_cars", "color")
+	var scopeErr *hubspot.ScopeError
+	if !errors.As(err, &scopeErr) {
+		t.Fatalf("Get() error is not a ScopeError: %#v", err)
+	}
+	want := "403: missing scopes for CRM.Properties.Get: crm.schemas.custom.read"
+	if scopeErr.Error() != want {
+		t.Errorf("Error() mismatch: want %s got %s", want, scopeErr.Error())
+	}
+
+	_, err = c.CRM.Objects("2-123").Create(map[string]string{"name": "car"})
+	if !errors.As(err, &scopeErr) {
+		t.Fatalf("Create() error is not a ScopeError: %#v", err)
+	}
+	want = "403: missing scopes for CRM.Objects.Create: crm.objects.custom.write"
+	if scopeErr.Error() != want {
+		t.Errorf("Error() mismatch: want %s got %s", want, scopeErr.Error())
+	}
+}
+
+func TestClient_CheckScopes(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations []string
+		wantErr    error
+	}{
+		{
+			name:       "All scopes granted",
+			operations: []string{"CRM.Contact.Get", "CRM.Contact.Create"},
+			wantErr:    nil,
+		},
+		{
+			name:       "Missing scopes",
+			operations: []string{"CRM.Contact.Create", "CRM.Imports.Start", "Marketing.Transactional.SendSingleEmail"},
+			wantErr: &hubspot.ScopeError{
+				Operations:    []string{"CRM.Imports.Start", "Marketing.Transactional.SendSingleEmail"},
+				MissingScopes: []string{"crm.import", "transactional-email"},
+			},
+		},
+		{
+			name:       "Scopes of the object type",
+			operations: []string{"CRM.Properties.Get/contact", "CRM.Properties.List/companies", "CRM.Properties.Create/p12345_cars", "CRM.Properties.Update/2-123"},
+			wantErr: &hubspot.ScopeError{
+				Operations:    []string{"CRM.Properties.Get/contact", "CRM.Properties.List/companies", "CRM.Properties.Create/p12345_cars", "CRM.Properties.Update/2-123"},
+				MissingScopes: []string{"crm.schemas.companies.read", "crm.schemas.contacts.read", "crm.schemas.custom.write"},
+			},
+		},
+		{
+			name:       "Scopes of the objects",
+			operations: []string{"CRM.Objects.Get/contacts", "CRM.Objects.Search/company", "CRM.Objects.BatchCreate/2-123", "CRM.Objects.Archive/p12345_cars", "CRM.Objects.Update/line_items"},
+			wantErr: &hubspot.ScopeError{
+				Operations:    []string{"CRM.Objects.Search/company", "CRM.Objects.BatchCreate/2-123", "CRM.Objects.Archive/p12345_cars", "CRM.Objects.Update/line_items"},
+				MissingScopes: []string{"crm.objects.companies.read", "crm.objects.custom.write", "crm.objects.line_items.write"},
+			},
+		},
+		{
+			name:       "Scopes of the object type IDs",
+			operations: []string{"CRM.Objects.Get/0-1", "CRM.Objects.Search/0-2", "CRM.Properties.Get/0-3", "CRM.Objects.Update/0-5"},
+			wantErr: &hubspot.ScopeError{
+				Operations:    []string{"CRM.Objects.Search/0-2", "CRM.Properties.Get/0-3", "CRM.Objects.Update/0-5"},
+				MissingScopes: []string{"crm.objects.companies.read", "crm.schemas.deals.read", "tickets"},
+			},
+		},
+		{
+			name:       "Missing object type",
+			operations: []string{"CRM.Properties.Get"},
+			wantErr:    errors.New("operation CRM.Properties.Get needs an object type, e.g. CRM.Properties.Get/contacts"),
+		},
+		{
+			name:       "Unknown operation",
+			operations: []string{"CRM.Contact.Merge"},
+			wantErr:    errors.New("unknown operation CRM.Contact.Merge"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("pat-na1-token"), hubspot.WithHTTPClient(&http.Client{
+				Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+					gotPath = req.URL.Path
+					return hubspot.NewMockHTTPClient(&hubspot.MockConfig{
+						Status: http.StatusOK,
+						Body:   []byte(`{"userId":123,"hubId":62515,"appId":456,"scopes":["oauth","crm.objects.contacts.read","crm.objects.contacts.write"]}`),
+					}).Transport.RoundTrip(req)
+				}),
+			}))
+
+			err := c.CheckScopes(context.Background(), tt.operations...)
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("CheckScopes() error mismatch: want %s got %s", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && gotPath != "/oauth/v2/private-apps/get/access-token-info" {
+				t.Errorf("token information path mismatch: got %s", gotPath)
+			}
+		})
+	}
+}
@@ -28,7 +28,7 @@ type SearchOptions struct {
 	Query        string        `json:"query,omitempty"`
 	Properties   []string      `json:"properties,omitempty"`
 	Limit        int           `json:"limit,omitempty"` // The maximum number of entries per page is 200, the default is 10.
-	After        int           `json:"after,omitempty"` // The offset for pagination, used to retrieve the next page of results.
+	After        string        `json:"after,omitempty"` // The cursor of the page, paging.next.after of the previous page.
 }
 
 // FilterGroup represents a group of filters for HubSpot search requests.
@@ -0,0 +1,240 @@
+package hubspot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The limits of a search request.
+// Reference: https://developers.hubspot.com/docs/guides/api/crm/search#limitations
+const (
+	MaxSearchFilterGroups    = 5
+	MaxSearchFiltersPerGroup = 6
+	MaxSearchFilters         = 18
+	MaxSearchLimit           = 200
+)
+
+// ErrInvalidSearch is wrapped by the errors of SearchOptions.Validate.
+// e.g. errors.Is(err, hubspot.ErrInvalidSearch)
+var ErrInvalidSearch = errors.New("invalid search")
+
+// FilterEQ returns a filter matching the objects whose property equals value.
+func FilterEQ(property, value string) Filter {
+	return Filter{PropertyName: property, Operator: EQ, Value: NewString(value)}
+}
+
+// FilterNEQ returns a filter matching the objects whose property does not equal value.
+func FilterNEQ(property, value string) Filter {
+	return Filter{PropertyName: property, Operator: NEQ, Value: NewString(value)}
+}
+
+// FilterLT returns a filter matching the objects whose property is less than value.
+func FilterLT(property, value string) Filter {
+	return Filter{PropertyName: property, Operator: LT, Value: NewString(value)}
+}
+
+// FilterLTE returns a filter matching the objects whose property is less than or equal to value.
+func FilterLTE(property, value string) Filter {
+	return Filter{PropertyName: property, Operator: LTE, Value: NewString(value)}
+}
+
+// FilterGT returns a filter matching the objects whose property is greater than value.
+func FilterGT(property, value string) Filter {
+	return Filter{PropertyName: property, Operator: GT, Value: NewString(value)}
+}
+
+// FilterGTE returns a filter matching the objects whose property is greater than or equal to value.
+func FilterGTE(property, value string) Filter {
+	return Filter{PropertyName: property, Operator: GTE, Value: NewString(value)}
+}
+
+// FilterBetween returns a filter matching the objects whose property is within low and high, inclusive.
+func FilterBetween(property, low, high string) Filter {
+	return Filter{PropertyName: property, Operator: Between, Value: NewString(low), HighValue: NewString(high)}
+}
+
+// FilterIN returns a filter matching the objects whose property is one of values.
+// The values are lowercased as HubSpot requires for string properties.
+func FilterIN(property string, values ...string) Filter {
+	return Filter{PropertyName: property, Operator: IN, Values: lowerValues(values)}
+}
+
+// FilterNotIN returns a filter matching the objects whose property is none of values.
+// The values are lowercased as HubSpot requires for string properties.
+func FilterNotIN(property string, values ...string) Filter {
+	return Filter{PropertyName: property, Operator: NotIN, Values: lowerValues(values)}
+}
+
+// FilterHasProperty returns a filter matching the objects which have a value for property.
+func FilterHasProperty(property string) Filter {
+	return Filter{PropertyName: property, Operator: HasProperty}
+}
+
+// FilterNotHasProperty returns a filter matching the objects which have no value for property.
+func FilterNotHasProperty(property string) Filter {
+	return Filter{PropertyName: property, Operator: NotHasProperty}
+}
+
+// FilterContainsToken returns a filter matching the objects whose property contains token.
+// The token can have wildcards, e.g. *@hubspot.com.
+func FilterContainsToken(property, token string) Filter {
+	return Filter{PropertyName: property, Operator: ContainsToken, Value: NewString(token)}
+}
+
+// FilterNotContainsToken returns a filter matching the objects whose property does not contain token.
+func FilterNotContainsToken(property, token string) Filter {
+	return Filter{PropertyName: property, Operator: NotContainsToken, Value: NewString(token)}
+}
+
+func lowerValues(values []string) []HsStr {
+	hs := make([]HsStr, 0, len(values))
+	for _, v := range values {
+		hs = append(hs, HsStr(strings.ToLower(v)))
+	}
+	return hs
+}
+
+// SearchBuilder builds SearchOptions fluently. The filters given to And are all required,
+// and Or starts another filter group, any of which has to match, e.g.
+//
+//	// (lifecyclestage = customer AND createdate >= 2024-01-01) OR email contains *@hubspot.com
+//	opts, err := hubspot.NewSearchBuilder().
+//		And(hubspot.FilterEQ("lifecyclestage", "customer"), hubspot.FilterGTE("createdate", "2024-01-01")).
+//		Or(hubspot.FilterContainsToken("email", "*@hubspot.com")).
+//		Sort("createdate", hubspot.Desc).
+//		Build()
+type SearchBuilder struct {
+	options SearchOptions
+}
+
+// NewSearchBuilder returns an empty search builder.
+func NewSearchBuilder() *SearchBuilder {
+	return &SearchBuilder{}
+}
+
+// And adds filters to the current filter group, all of which have to match.
+func (b *SearchBuilder) And(filters ...Filter) *SearchBuilder {
+	if len(b.options.FilterGroups) == 0 {
+		return b.Or(filters...)
+	}
+	g := &b.options.FilterGroups[len(b.options.FilterGroups)-1]
+	g.Filters = append(g.Filters, filters...)
+	return b
+}
+
+// Or starts a new filter group with filters. The objects matching any of the groups are returned.
+func (b *SearchBuilder) Or(filters ...Filter) *SearchBuilder {
+	b.options.FilterGroups = append(b.options.FilterGroups, FilterGroup{Filters: append([]Filter(nil), filters...)})
+	return b
+}
+
+// Query sets the text searched in the default searchable properties.
+func (b *SearchBuilder) Query(query string) *SearchBuilder {
+	b.options.Query = query
+	return b
+}
+
+// Properties sets the properties to be returned.
+func (b *SearchBuilder) Properties(properties ...string) *SearchBuilder {
+	b.options.Properties = append(b.options.Properties, properties...)
+	return b
+}
+
+// Sort sorts the results by property.
+func (b *SearchBuilder) Sort(property string, direction SortDirection) *SearchBuilder {
+	b.options.Sorts = append(b.options.Sorts, Sort{PropertyName: property, Direction: direction})
+	return b
+}
+
+// Limit sets the number of results per page, 200 at most.
+func (b *SearchBuilder) Limit(limit int) *SearchBuilder {
+	b.options.Limit = limit
+	return b
+}
+
+// After sets the cursor of the page.
+func (b *SearchBuilder) After(after string) *SearchBuilder {
+	b.options.After = after
+	return b
+}
+
+// Build validates and returns the search options.
+func (b *SearchBuilder) Build() (*SearchOptions, error) {
+	opts := b.options
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &opts, nil
+}
+
+// Validate checks the options against the limits of HubSpot, and the values given to each operator.
+// The errors wrap ErrInvalidSearch.
+func (o *SearchOptions) Validate() error {
+	if len(o.FilterGroups) > MaxSearchFilterGroups {
+		return fmt.Errorf("%w: %d filter groups, %d at most", ErrInvalidSearch, len(o.FilterGroups), MaxSearchFilterGroups)
+	}
+	var total int
+	for i, g := range o.FilterGroups {
+		if len(g.Filters) == 0 {
+			return fmt.Errorf("%w: filter group %d has no filters", ErrInvalidSearch, i)
+		}
+		if len(g.Filters) > MaxSearchFiltersPerGroup {
+			return fmt.Errorf("%w: filter group %d has %d filters, %d at most", ErrInvalidSearch, i, len(g.Filters), MaxSearchFiltersPerGroup)
+		}
+		total += len(g.Filters)
+		for j, f := range g.Filters {
+			if err := f.validate(); err != nil {
+				return fmt.Errorf("%w: filter %d of group %d: %s", ErrInvalidSearch, j, i, err)
+			}
+		}
+	}
+	if total > MaxSearchFilters {
+		return fmt.Errorf("%w: %d filters, %d at most", ErrInvalidSearch, total, MaxSearchFilters)
+	}
+	for _, s := range o.Sorts {
+		if s.PropertyName == "" {
+			return fmt.Errorf("%w: sort without property name", ErrInvalidSearch)
+		}
+		if s.Direction != Asc && s.Direction != Desc {
+			return fmt.Errorf("%w: unknown sort direction %q", ErrInvalidSearch, s.Direction)
+		}
+	}
+	if o.Limit < 0 || o.Limit > MaxSearchLimit {
+		return fmt.Errorf("%w: limit %d, between 0 and %d", ErrInvalidSearch, o.Limit, MaxSearchLimit)
+	}
+	return nil
+}
+
+// validate checks that the filter has the values its operator takes.
+func (f *Filter) validate() error {
+	if f.PropertyName == "" {
+		return errors.New("missing property name")
+	}
+	switch f.Operator {
+	case EQ, NEQ, LT, LTE, GT, GTE, ContainsToken, NotContainsToken:
+		if f.Value == nil || f.HighValue != nil || len(f.Values) > 0 {
+			return fmt.Errorf("%s on %s takes a single value", f.Operator, f.PropertyName)
+		}
+	case Between:
+		if f.Value == nil || f.HighValue == nil || len(f.Values) > 0 {
+			return fmt.Errorf("%s on %s takes a value and a high value", f.Operator, f.PropertyName)
+		}
+	case IN, NotIN:
+		if len(f.Values) == 0 || f.Value != nil || f.HighValue != nil {
+			return fmt.Errorf("%s on %s takes a list of values", f.Operator, f.PropertyName)
+		}
+		for _, v := range f.Values {
+			if s := string(v); s != strings.ToLower(s) {
+				return fmt.Errorf("%s on %s has the value %q, which must be lowercase", f.Operator, f.PropertyName, s)
+			}
+		}
+	case HasProperty, NotHasProperty:
+		if f.Value != nil || f.HighValue != nil || len(f.Values) > 0 {
+			return fmt.Errorf("%s on %s takes no value", f.Operator, f.PropertyName)
+		}
+	default:
+		return fmt.Errorf("unknown operator %q on %s", f.Operator, f.PropertyName)
+	}
+	return nil
+}
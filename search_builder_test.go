@@ -0,0 +1,143 @@
+package hubspot_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot"
+)
+
+func TestSearchBuilder_Build(t *testing.T) {
+	opts, err := hubspot.NewSearchBuilder().
+		And(hubspot.FilterEQ("lifecyclestage", "customer"), hubspot.FilterBetween("createdate", "2024-01-01", "2024-12-31")).
+		And(hubspot.FilterHasProperty("phone")).
+		Or(hubspot.FilterIN("email", "A@Example.com", "b@example.com")).
+		Or(hubspot.FilterContainsToken("email", "*@hubspot.com")).
+		Properties("email", "phone").
+		Sort("createdate", hubspot.Desc).
+		Limit(100).
+		After("200").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error: %s", err)
+	}
+
+	got, _ := json.Marshal(opts)
+	want := `{"filterGroups":[` +
+		`{"filters":[{"propertyName":"lifecyclestage","operator":"EQ","value":"customer"},{"propertyName":"createdate","operator":"BETWEEN","value":"2024-01-01","highValue":"2024-12-31"},{"propertyName":"phone","operator":"HAS_PROPERTY"}]},` +
+		`{"filters":[{"propertyName":"email","operator":"IN","values":["a@example.com","b@example.com"]}]},` +
+		`{"filters":[{"propertyName":"email","operator":"CONTAINS_TOKEN","value":"*@hubspot.com"}]}],` +
+		`"sorts":[{"propertyName":"createdate","direction":"DESCENDING"}],"properties":["email","phone"],"limit":100,"after":"200"}`
+	if string(got) != want {
+		t.Errorf("Build() mismatch:\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestSearchOptions_Validate(t *testing.T) {
+	filters := func(n int) []hubspot.Filter {
+		fs := make([]hubspot.Filter, 0, n)
+		for i := 0; i < n; i++ {
+			fs = append(fs, hubspot.FilterEQ(fmt.Sprintf("p%d", i), "v"))
+		}
+		return fs
+	}
+
+	tests := []struct {
+		name    string
+		builder *hubspot.SearchBuilder
+		wantErr string
+	}{
+		{
+			name:    "Empty search",
+			builder: hubspot.NewSearchBuilder(),
+		},
+		{
+			name:    "Filters at the limits",
+			builder: hubspot.NewSearchBuilder().And(filters(6)...).Or(filters(6)...).Or(filters(6)...),
+		},
+		{
+			name:    "Too many filter groups",
+			builder: hubspot.NewSearchBuilder().Or(filters(1)...).Or(filters(1)...).Or(filters(1)...).Or(filters(1)...).Or(filters(1)...).Or(filters(1)...),
+			wantErr: "invalid search: 6 filter groups, 5 at most",
+		},
+		{
+			name:    "Too many filters in a group",
+			builder: hubspot.NewSearchBuilder().And(filters(7)...),
+			wantErr: "invalid search: filter group 0 has 7 filters, 6 at most",
+		},
+		{
+			name:    "Too many filters",
+			builder: hubspot.NewSearchBuilder().Or(filters(5)...).Or(filters(5)...).Or(filters(5)...).Or(filters(4)...),
+			wantErr: "invalid search: 19 filters, 18 at most",
+		},
+		{
+			name:    "Empty filter group",
+			builder: hubspot.NewSearchBuilder().Or(),
+			wantErr: "invalid search: filter group 0 has no filters",
+		},
+		{
+			name:    "Uppercase IN value",
+			builder: hubspot.NewSearchBuilder().And(hubspot.Filter{PropertyName: "email", Operator: hubspot.IN, Values: []hubspot.HsStr{"A@example.com"}}),
+			wantErr: `invalid search: filter 0 of group 0: IN on email has the value "A@example.com", which must be lowercase`,
+		},
+		{
+			name:    "IN without values",
+			builder: hubspot.NewSearchBuilder().And(hubspot.FilterNotIN("email")),
+			wantErr: "invalid search: filter 0 of group 0: NOT_IN on email takes a list of values",
+		},
+		{
+			name:    "BETWEEN without high value",
+			builder: hubspot.NewSearchBuilder().And(hubspot.Filter{PropertyName: "amount", Operator: hubspot.Between, Value: hubspot.NewString("1")}),
+			wantErr: "invalid search: filter 0 of group 0: BETWEEN on amount takes a value and a high value",
+		},
+		{
+			name:    "HAS_PROPERTY with a value",
+			builder: hubspot.NewSearchBuilder().And(hubspot.Filter{PropertyName: "phone", Operator: hubspot.HasProperty, Value: hubspot.NewString("1")}),
+			wantErr: "invalid search: filter 0 of group 0: HAS_PROPERTY on phone takes no value",
+		},
+		{
+			name:    "EQ without value",
+			builder: hubspot.NewSearchBuilder().And(hubspot.Filter{PropertyName: "email", Operator: hubspot.EQ}),
+			wantErr: "invalid search: filter 0 of group 0: EQ on email takes a single value",
+		},
+		{
+			name:    "Missing property name",
+			builder: hubspot.NewSearchBuilder().And(hubspot.FilterHasProperty("")),
+			wantErr: "invalid search: filter 0 of group 0: missing property name",
+		},
+		{
+			name:    "Unknown operator",
+			builder: hubspot.NewSearchBuilder().And(hubspot.Filter{PropertyName: "email", Operator: "LIKE", Value: hubspot.NewString("x")}),
+			wantErr: `invalid search: filter 0 of group 0: unknown operator "LIKE" on email`,
+		},
+		{
+			name:    "Unknown sort direction",
+			builder: hubspot.NewSearchBuilder().Sort("createdate", "UP"),
+			wantErr: `invalid search: unknown sort direction "UP"`,
+		},
+		{
+			name:    "Limit too large",
+			builder: hubspot.NewSearchBuilder().Limit(201),
+			wantErr: "invalid search: limit 201, between 0 and 200",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Build() error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("Build() error mismatch: want %s got %v", tt.wantErr, err)
+			}
+			if !errors.Is(err, hubspot.ErrInvalidSearch) {
+				t.Errorf("Build() error is not ErrInvalidSearch: %v", err)
+			}
+		})
+	}
+}
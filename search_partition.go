@@ -0,0 +1,170 @@
+package hubspot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultPartitionProperty = "hs_object_id"
+
+// maxSearchResults is the number of results HubSpot returns for a search at most, however many pages are requested.
+var maxSearchResults = 10000
+
+// SearchPartitionOption is a set of options to search beyond the 10,000 results HubSpot returns for a search.
+type SearchPartitionOption struct {
+	// Property is the numeric or date property the search is partitioned by, hs_object_id by default.
+	// The objects which have no value for a date property are skipped after the first 10,000 results.
+	Property string
+	// PagerOption.PageSize is 200 by default.
+	// PagerOption.After is Pager.Cursor of a previous partitioned search. Resuming from it may return objects already returned
+	// when the property is a date, which several objects can share.
+	PagerOption
+}
+
+// NewPartitionedSearchPager returns a pager searching the objects of a service, e.g. client.CRM.Objects(hubspot.ObjectTypeContact),
+// beyond the 10,000 results HubSpot returns for a search.
+// The results are sorted by option.Property, and each time the cap is about to be reached a new search starts after
+// the last value returned. The objects are returned once, even when they are found by several searches.
+// A filter on the property is added to every filter group of req, so they must have room for one more filter.
+func NewPartitionedSearchPager(s ObjectService, req *ObjectSearchRequest, option *SearchPartitionOption) *Pager {
+	var opts SearchOptions
+	if req != nil {
+		opts = req.SearchOptions
+	}
+	p := newPartitionedSearchPager(func(ctx context.Context, opts *SearchOptions) (*ObjectList, error) {
+		return s.SearchWithContext(ctx, &ObjectSearchRequest{SearchOptions: *opts})
+	}, opts, option)
+	p.trace = objectsTracer(s, "CRM.Objects.Search")
+	return p
+}
+
+// newPartitionedSearchPager returns a pager running the partitions of opts with search.
+// Its cursor is the boundary value of the current partition and the cursor within the partition, separated with a colon.
+func newPartitionedSearchPager(search func(ctx context.Context, opts *SearchOptions) (*ObjectList, error), opts SearchOptions, option *SearchPartitionOption) *Pager {
+	var o SearchPartitionOption
+	if option != nil {
+		o = *option
+	}
+	if o.Property == "" {
+		o.Property = defaultPartitionProperty
+	}
+	if o.PageSize == 0 {
+		o.PageSize = MaxSearchLimit
+	}
+	// Objects sharing a date are found again by the next partition, which starts at that date.
+	op := GTE
+	if o.Property == defaultPartitionProperty {
+		op = GT
+	}
+	opts.Sorts = []Sort{{PropertyName: o.Property, Direction: Asc}}
+	if len(opts.Properties) > 0 && !containsString(opts.Properties, o.Property) {
+		opts.Properties = append(append([]string(nil), opts.Properties...), o.Property)
+	}
+
+	// The objects found again are the ones at the boundary of the next partition, so only the IDs of the objects
+	// sharing the last value returned are kept.
+	var lastValue string
+	atLastValue := map[string]bool{}
+	return NewPager(func(ctx context.Context, cursor string, limit int) ([]*ResponseResource, string, error) {
+		boundary, after := splitPartitionCursor(cursor)
+		sub := opts
+		sub.FilterGroups = withPartitionFilter(opts.FilterGroups, o.Property, op, boundary)
+		sub.After, sub.Limit = after, limit
+		if err := sub.Validate(); err != nil {
+			return nil, "", err
+		}
+		list, err := search(ctx, &sub)
+		if err != nil {
+			return nil, "", err
+		}
+
+		results := list.Results
+		if op == GTE {
+			results = make([]*ResponseResource, 0, len(list.Results))
+			for _, r := range list.Results {
+				if v, _ := partitionValue(r, o.Property); v != lastValue {
+					lastValue = v
+					atLastValue = map[string]bool{}
+				}
+				if atLastValue[r.ID] {
+					continue
+				}
+				atLastValue[r.ID] = true
+				results = append(results, r)
+			}
+		}
+
+		next := list.nextCursor()
+		if next == "" {
+			return results, "", nil
+		}
+		if offset, err := strconv.Atoi(next); err != nil || offset+limit <= maxSearchResults {
+			return results, boundary + ":" + next, nil
+		}
+		// The cap is about to be reached: the next partition starts at the last value returned.
+		if len(list.Results) == 0 {
+			return results, "", nil
+		}
+		last, ok := partitionValue(list.Results[len(list.Results)-1], o.Property)
+		if !ok {
+			return nil, "", fmt.Errorf("the search results have no %s to partition by", o.Property)
+		}
+		if last == boundary {
+			return nil, "", fmt.Errorf("more than %d objects have %s %s, the search cannot be partitioned by it", maxSearchResults, o.Property, last)
+		}
+		return results, last + ":", nil
+	}, &o.PagerOption)
+}
+
+// withPartitionFilter returns the filter groups restricted to the partition starting at boundary.
+func withPartitionFilter(groups []FilterGroup, property string, op Operator, boundary string) []FilterGroup {
+	if boundary == "" {
+		return groups
+	}
+	f := Filter{PropertyName: property, Operator: op, Value: NewString(boundary)}
+	if len(groups) == 0 {
+		return []FilterGroup{{Filters: []Filter{f}}}
+	}
+	partitioned := make([]FilterGroup, 0, len(groups))
+	for _, g := range groups {
+		filters := append(append([]Filter(nil), g.Filters...), f)
+		partitioned = append(partitioned, FilterGroup{Filters: filters})
+	}
+	return partitioned
+}
+
+func splitPartitionCursor(cursor string) (boundary, after string) {
+	i := strings.LastIndex(cursor, ":")
+	if i < 0 {
+		return "", cursor
+	}
+	return cursor[:i], cursor[i+1:]
+}
+
+// partitionValue returns the value of the property of an object, dates being converted to milliseconds as HubSpot filters take them.
+func partitionValue(r *ResponseResource, property string) (string, bool) {
+	props, ok := r.Properties.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	v, ok := props[property].(string)
+	if !ok || v == "" {
+		return "", false
+	}
+	if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10), true
+	}
+	return v, true
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
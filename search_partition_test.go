@@ -0,0 +1,263 @@
+package hubspot_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeSearch serves the search endpoint over objects, enforcing the cap on the number of results of a search.
+// The objects are the values of the properties by ID, the dates being in milliseconds.
+type fakeSearch struct {
+	t       *testing.T
+	cap     int
+	objects map[string]map[string]int64
+	paths   []string
+	filters [][]hubspot.FilterGroup
+}
+
+func (f *fakeSearch) client() *hubspot.Client {
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"), hubspot.WithHTTPClient(&http.Client{
+		Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+			status, body := f.serve(req)
+			return hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: status, Body: []byte(body)}).Transport.RoundTrip(req)
+		}),
+	}))
+	return c
+}
+
+func (f *fakeSearch) serve(req *http.Request) (int, string) {
+	var opts hubspot.SearchOptions
+	b, _ := ioutil.ReadAll(req.Body)
+	if err := json.Unmarshal(b, &opts); err != nil {
+		f.t.Fatalf("request body is not JSON: %s", err)
+	}
+	f.paths = append(f.paths, req.URL.Path)
+	f.filters = append(f.filters, opts.FilterGroups)
+
+	offset, _ := strconv.Atoi(opts.After)
+	if offset+opts.Limit > f.cap {
+		return http.StatusBadRequest, `{"status":"error","message":"offset too large","category":"VALIDATION_ERROR"}`
+	}
+	property := opts.Sorts[0].PropertyName
+	var ids []string
+	for id, props := range f.objects {
+		if f.match(props, opts.FilterGroups) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		vi, vj := f.objects[ids[i]][property], f.objects[ids[j]][property]
+		if vi != vj {
+			return vi < vj
+		}
+		return ids[i] < ids[j]
+	})
+
+	type result struct {
+		ID         string            `json:"id"`
+		Properties map[string]string `json:"properties"`
+	}
+	res := struct {
+		Total   int            `json:"total"`
+		Results []result       `json:"results"`
+		Paging  hubspot.Paging `json:"paging"`
+	}{Total: len(ids), Results: []result{}}
+	for i := offset; i < len(ids) && i < offset+opts.Limit; i++ {
+		props := f.objects[ids[i]]
+		res.Results = append(res.Results, result{ID: ids[i], Properties: map[string]string{
+			"hs_object_id": fmt.Sprint(props["hs_object_id"]),
+			"createdate":   time.Unix(0, props["createdate"]*int64(time.Millisecond)).UTC().Format("2006-01-02T15:04:05.000Z"),
+		}})
+	}
+	if offset+opts.Limit < len(ids) {
+		res.Paging.Next = &hubspot.PagingData{After: fmt.Sprint(offset + opts.Limit)}
+	}
+	body, _ := json.Marshal(res)
+	return http.StatusOK, string(body)
+}
+
+func (f *fakeSearch) match(props map[string]int64, groups []hubspot.FilterGroup) bool {
+	if len(groups) == 0 {
+		return true
+	}
+	for _, g := range groups {
+		ok := true
+		for _, filter := range g.Filters {
+			v, _ := strconv.ParseInt(string(*filter.Value), 10, 64)
+			switch filter.Operator {
+			case hubspot.GT:
+				ok = ok && props[filter.PropertyName] > v
+			case hubspot.GTE:
+				ok = ok && props[filter.PropertyName] >= v
+			case hubspot.LT:
+				ok = ok && props[filter.PropertyName] < v
+			default:
+				f.t.Fatalf("unexpected operator %s", filter.Operator)
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// newFakeObjects returns n objects created two by two at the same date.
+func newFakeObjects(n int) map[string]map[string]int64 {
+	objects := map[string]map[string]int64{}
+	for i := 1; i <= n; i++ {
+		objects[fmt.Sprint(i)] = map[string]int64{"hs_object_id": int64(i), "createdate": int64(1600000000000 + (i/2)*1000)}
+	}
+	return objects
+}
+
+func TestNewPartitionedSearchPager(t *testing.T) {
+	defer hubspot.MockMaxSearchResults(10)()
+
+	tests := []struct {
+		name     string
+		objects  map[string]map[string]int64
+		req      *hubspot.ObjectSearchRequest
+		option   *hubspot.SearchPartitionOption
+		wantIDs  int
+		wantReqs int
+		wantErr  string
+	}{
+		{
+			name:     "Partition by object ID",
+			objects:  newFakeObjects(25),
+			option:   &hubspot.SearchPartitionOption{PagerOption: hubspot.PagerOption{PageSize: 4}},
+			wantIDs:  25,
+			wantReqs: 7,
+		},
+		{
+			name:    "Partition by date",
+			objects: newFakeObjects(25),
+			option: &hubspot.SearchPartitionOption{
+				Property:    "createdate",
+				PagerOption: hubspot.PagerOption{PageSize: 5},
+			},
+			wantIDs:  25,
+			wantReqs: 6,
+		},
+		{
+			name: "Objects sharing a date across partitions",
+			objects: func() map[string]map[string]int64 {
+				objects := newFakeObjects(25)
+				for i := 8; i <= 13; i++ {
+					objects[fmt.Sprint(i)]["createdate"] = 1600000004000
+				}
+				return objects
+			}(),
+			option: &hubspot.SearchPartitionOption{
+				Property:    "createdate",
+				PagerOption: hubspot.PagerOption{PageSize: 5},
+			},
+			wantIDs:  25,
+			wantReqs: 6,
+		},
+		{
+			name:    "Partition every filter group",
+			objects: newFakeObjects(25),
+			req: &hubspot.ObjectSearchRequest{SearchOptions: *mustBuild(t, hubspot.NewSearchBuilder().
+				Or(hubspot.FilterLT("hs_object_id", "6")).
+				Or(hubspot.FilterGT("hs_object_id", "10")),
+			)},
+			option:   &hubspot.SearchPartitionOption{PagerOption: hubspot.PagerOption{PageSize: 5}},
+			wantIDs:  20,
+			wantReqs: 4,
+		},
+		{
+			name: "Too many objects at the same date",
+			objects: func() map[string]map[string]int64 {
+				objects := newFakeObjects(25)
+				for _, props := range objects {
+					props["createdate"] = 1600000000000
+				}
+				return objects
+			}(),
+			option: &hubspot.SearchPartitionOption{
+				Property:    "createdate",
+				PagerOption: hubspot.PagerOption{PageSize: 5},
+			},
+			wantIDs:  10,
+			wantReqs: 4,
+			wantErr:  "more than 10 objects have createdate 1600000000000, the search cannot be partitioned by it",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeSearch{t: t, cap: 10, objects: tt.objects}
+			p := hubspot.NewPartitionedSearchPager(f.client().CRM.Objects(hubspot.ObjectTypeContact), tt.req, tt.option)
+
+			seen := map[string]bool{}
+			for p.Next(context.Background()) {
+				id := p.Object().ID
+				if seen[id] {
+					t.Errorf("object %s returned twice", id)
+				}
+				seen[id] = true
+			}
+			if err := p.Err(); (err == nil && tt.wantErr != "") || (err != nil && err.Error() != tt.wantErr) {
+				t.Fatalf("Err() mismatch: want %q got %v", tt.wantErr, err)
+			}
+			if len(seen) != tt.wantIDs {
+				t.Errorf("objects mismatch: want %d got %d", tt.wantIDs, len(seen))
+			}
+			if len(f.paths) != tt.wantReqs {
+				t.Errorf("requests mismatch: want %d got %d", tt.wantReqs, len(f.paths))
+			}
+		})
+	}
+}
+
+func TestContactServiceOp_SearchAll(t *testing.T) {
+	defer hubspot.MockMaxSearchResults(10)()
+
+	f := &fakeSearch{t: t, cap: 10, objects: newFakeObjects(15)}
+	p := f.client().CRM.Contact.SearchAll(&hubspot.ContactSearchRequest{}, &hubspot.SearchPartitionOption{PagerOption: hubspot.PagerOption{PageSize: 5}})
+	var ids []string
+	for p.Next(context.Background()) {
+		ids = append(ids, p.Object().ID)
+	}
+	if err := p.Err(); err != nil {
+		t.Fatalf("Err() error: %s", err)
+	}
+
+	want := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15"}
+	if diff := cmp.Diff(want, ids); diff != "" {
+		t.Errorf("objects mismatch (-want +got):%s", diff)
+	}
+	wantFilters := [][]hubspot.FilterGroup{
+		nil,
+		nil,
+		{{Filters: []hubspot.Filter{{PropertyName: "hs_object_id", Operator: hubspot.GT, Value: hubspot.NewString("10")}}}},
+	}
+	if diff := cmp.Diff(wantFilters, f.filters); diff != "" {
+		t.Errorf("filters mismatch (-want +got):%s", diff)
+	}
+	for _, path := range f.paths {
+		if path != "/crm/v3/objects/contacts/search" {
+			t.Errorf("path mismatch: want /crm/v3/objects/contacts/search got %s", path)
+		}
+	}
+}
+
+func mustBuild(t *testing.T, b *hubspot.SearchBuilder) *hubspot.SearchOptions {
+	t.Helper()
+	opts, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error: %s", err)
+	}
+	return opts
+}
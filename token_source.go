@@ -0,0 +1,224 @@
+package hubspot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTokenCacheTTL = time.Minute
+	// rejectedTokenBackoff is how long a rejected primary token is not sent again, unless the primary source changes.
+	rejectedTokenBackoff = 15 * time.Minute
+)
+
+// TokenSource returns the current private app token.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc is an adapter to use an ordinary function as a TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// FileTokenSource returns a TokenSource reading the token from a file, e.g. a mounted secret.
+func FileTokenSource(path string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		token := strings.TrimSpace(string(b))
+		if token == "" {
+			return "", fmt.Errorf("the token file %s is empty", path)
+		}
+		return token, nil
+	})
+}
+
+// EnvTokenSource returns a TokenSource reading the token from an environment variable.
+func EnvTokenSource(name string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		token := os.Getenv(name)
+		if token == "" {
+			return "", fmt.Errorf("the environment variable %s is not set", name)
+		}
+		return token, nil
+	})
+}
+
+// PrivateAppTokenSourceConfig is the configuration of SetPrivateAppTokenSource.
+type PrivateAppTokenSourceConfig struct {
+	// Primary is the source of the token used by default.
+	Primary TokenSource
+	// Secondary is the source of the token tried when the primary one is rejected, e.g. the next token during a rotation.
+	Secondary TokenSource
+	// CacheTTL is how long a token read from a source is used before the source is read again. It is 1 minute by default.
+	CacheTTL time.Duration
+}
+
+// SetPrivateAppTokenSource authenticates with a private app token read from a TokenSource,
+// so that the token can be rotated without restarting the service.
+// When HubSpot rejects the token with 401, the primary source is read again and then the secondary token is tried,
+// before the request fails. Once the primary token has been rejected, the secondary token is used until the primary
+// source returns another token, or for 15 minutes before the primary token is tried again.
+func SetPrivateAppTokenSource(config *PrivateAppTokenSourceConfig) AuthMethod {
+	return func(c *Client) {
+		c.authenticator = &PrivateAppTokenSource{
+			config: config,
+		}
+	}
+}
+
+// PrivateAppTokenSource is the Authenticator set by SetPrivateAppTokenSource.
+type PrivateAppTokenSource struct {
+	config *PrivateAppTokenSourceConfig
+
+	mu       sync.Mutex
+	token    string
+	cachedAt time.Time
+	// rejectedPrimary is the token of the primary source rejected by HubSpot at rejectedAt.
+	rejectedPrimary string
+	rejectedAt      time.Time
+}
+
+var _ reauthenticator = (*PrivateAppTokenSource)(nil)
+
+func (p *PrivateAppTokenSource) SetAuthentication(r *http.Request) error {
+	token, err := p.current(r.Context())
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// current returns the cached token, reading the primary source when the cache has expired,
+// or the secondary one while the primary token is known to be rejected.
+func (p *PrivateAppTokenSource) current(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && timeNow().Sub(p.cachedAt) < p.cacheTTL() {
+		return p.token, nil
+	}
+	if p.config.Primary == nil {
+		return "", errors.New("the primary token source is not set")
+	}
+	token, err := p.config.Primary.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the private app token: %w", err)
+	}
+	if token == p.rejectedPrimary && timeNow().Sub(p.rejectedAt) < rejectedTokenBackoff && p.config.Secondary != nil {
+		// Sending the primary token again would only cost a rejected request.
+		if secondary, err := p.config.Secondary.Token(ctx); err == nil && secondary != "" {
+			token = secondary
+		}
+	}
+	p.token, p.cachedAt = token, timeNow()
+	return token, nil
+}
+
+// reauthenticate sets the first token which has not been rejected yet: the primary one read again, then the secondary one.
+// The token found is cached, so that the next requests do not send the rejected one.
+func (p *PrivateAppTokenSource) reauthenticate(r *http.Request, rejected []string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	isRejected := func(token string) bool {
+		for _, h := range rejected {
+			if h == "Bearer "+token {
+				return true
+			}
+		}
+		return false
+	}
+	if isRejected(p.token) {
+		p.token = ""
+	}
+
+	for i, source := range []TokenSource{p.config.Primary, p.config.Secondary} {
+		if source == nil {
+			continue
+		}
+		token, err := source.Token(r.Context())
+		if err != nil || token == "" {
+			continue
+		}
+		if isRejected(token) {
+			if i == 0 {
+				p.rejectedPrimary, p.rejectedAt = token, timeNow()
+			}
+			continue
+		}
+		p.token, p.cachedAt = token, timeNow()
+		r.Header.Set("Authorization", "Bearer "+token)
+		return true
+	}
+	return false
+}
+
+func (p *PrivateAppTokenSource) cacheTTL() time.Duration {
+	if p.config.CacheTTL <= 0 {
+		return defaultTokenCacheTTL
+	}
+	return p.config.CacheTTL
+}
+
+// reauthenticator is implemented by the authenticators which can authenticate a request again
+// after HubSpot has rejected it with 401, e.g. with a rotated token.
+type reauthenticator interface {
+	// reauthenticate sets other credentials than the rejected Authorization headers to the request,
+	// and reports whether it has found any.
+	reauthenticate(r *http.Request, rejected []string) bool
+}
+
+// doWithReauthentication sends the request with doWithRetry, and sends it again with other credentials
+// while it is rejected with 401 and the authenticator has some.
+// It returns the last response or error along with the number of attempts made.
+func (c *Client) doWithReauthentication(req *http.Request) (*http.Response, int, error) {
+	ra, ok := c.authenticator.(reauthenticator)
+	if !ok {
+		return c.doWithRetry(req)
+	}
+
+	var rejected []string
+	total := 0
+	for {
+		resp, attempts, err := c.doWithRetry(req)
+		total += attempts
+		if err != nil || resp.StatusCode != http.StatusUnauthorized {
+			return resp, total, err
+		}
+
+		rejected = append(rejected, req.Header.Get("Authorization"))
+		r := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, total, nil
+			}
+			r.Body = body
+		} else if req.Body != nil && req.Body != http.NoBody {
+			// The body has been consumed and cannot be sent again.
+			return resp, total, nil
+		}
+		if !ra.reauthenticate(r, rejected) {
+			return resp, total, nil
+		}
+
+		// Drain the body so that the connection can be reused.
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		req = r
+	}
+}
@@ -0,0 +1,208 @@
+package hubspot_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPrivateAppTokenSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		primary       []string
+		secondary     string
+		valid         map[string]bool
+		wantAuth      []string
+		wantPrimary   int
+		wantErrStatus int
+	}{
+		{
+			name:        "Cached token",
+			primary:     []string{"token_a"},
+			valid:       map[string]bool{"token_a": true},
+			wantAuth:    []string{"Bearer token_a", "Bearer token_a"},
+			wantPrimary: 1,
+		},
+		{
+			name:        "Rotated primary token",
+			primary:     []string{"token_a", "token_b"},
+			valid:       map[string]bool{"token_b": true},
+			wantAuth:    []string{"Bearer token_a", "Bearer token_b", "Bearer token_b"},
+			wantPrimary: 2,
+		},
+		{
+			name:        "Secondary token",
+			primary:     []string{"token_a"},
+			secondary:   "token_b",
+			valid:       map[string]bool{"token_b": true},
+			wantAuth:    []string{"Bearer token_a", "Bearer token_b", "Bearer token_b"},
+			wantPrimary: 2,
+		},
+		{
+			name:          "Revoked tokens",
+			primary:       []string{"token_a"},
+			secondary:     "token_b",
+			valid:         map[string]bool{},
+			wantAuth:      []string{"Bearer token_a", "Bearer token_b"},
+			wantPrimary:   3,
+			wantErrStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var primaryReads int
+			config := &hubspot.PrivateAppTokenSourceConfig{
+				Primary: hubspot.TokenSourceFunc(func(context.Context) (string, error) {
+					i := primaryReads
+					if i >= len(tt.primary) {
+						i = len(tt.primary) - 1
+					}
+					primaryReads++
+					return tt.primary[i], nil
+				}),
+			}
+			if tt.secondary != "" {
+				config.Secondary = hubspot.TokenSourceFunc(func(context.Context) (string, error) {
+					return tt.secondary, nil
+				})
+			}
+
+			var gotAuth []string
+			var gotBodies []string
+			c, _ := hubspot.NewClient(hubspot.SetPrivateAppTokenSource(config), hubspot.WithHTTPClient(&http.Client{
+				Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+					auth := req.Header.Get("Authorization")
+					gotAuth = append(gotAuth, auth)
+					b, _ := ioutil.ReadAll(req.Body)
+					gotBodies = append(gotBodies, string(b))
+					mock := &hubspot.MockConfig{Status: http.StatusOK, Body: []byte(`{}`)}
+					if !tt.valid[auth[len("Bearer "):]] {
+						mock = &hubspot.MockConfig{
+							Status: http.StatusUnauthorized,
+							Body:   []byte(`{"status":"error","message":"Authentication credentials not found.","category":"INVALID_AUTHENTICATION"}`),
+						}
+					}
+					return hubspot.NewMockHTTPClient(mock).Transport.RoundTrip(req)
+				}),
+			}))
+
+			for i := 0; i < 2; i++ {
+				err := c.Post("crm/v3/objects/contacts", map[string]string{"id": "1"}, nil)
+				if tt.wantErrStatus == 0 {
+					if err != nil {
+						t.Fatalf("Post() error: %s", err)
+					}
+					continue
+				}
+				var apiErr *hubspot.APIError
+				if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != tt.wantErrStatus {
+					t.Fatalf("Post() error mismatch: want status %d got %v", tt.wantErrStatus, err)
+				}
+				break
+			}
+
+			if diff := cmp.Diff(tt.wantAuth, gotAuth); diff != "" {
+				t.Errorf("Authorization mismatch (-want +got):%s", diff)
+			}
+			for _, b := range gotBodies {
+				if b != `{"id":"1"}` {
+					t.Errorf("the body must be sent again: got %s", b)
+				}
+			}
+			if primaryReads != tt.wantPrimary {
+				t.Errorf("primary reads mismatch: want %d got %d", tt.wantPrimary, primaryReads)
+			}
+		})
+	}
+}
+
+func TestPrivateAppTokenSource_RejectedPrimary(t *testing.T) {
+	now := time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC)
+	defer hubspot.MockTimeNowFunc(func() time.Time { return now })()
+
+	primary := "token_a"
+	config := &hubspot.PrivateAppTokenSourceConfig{
+		Primary:   hubspot.TokenSourceFunc(func(context.Context) (string, error) { return primary, nil }),
+		Secondary: hubspot.TokenSourceFunc(func(context.Context) (string, error) { return "token_b", nil }),
+	}
+	var gotAuth []string
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppTokenSource(config), hubspot.WithHTTPClient(&http.Client{
+		Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+			auth := req.Header.Get("Authorization")
+			gotAuth = append(gotAuth, auth)
+			mock := &hubspot.MockConfig{Status: http.StatusOK, Body: []byte(`{}`)}
+			if auth == "Bearer token_a" {
+				mock = &hubspot.MockConfig{Status: http.StatusUnauthorized, Body: []byte(`{"status":"error","category":"INVALID_AUTHENTICATION"}`)}
+			}
+			return hubspot.NewMockHTTPClient(mock).Transport.RoundTrip(req)
+		}),
+	}))
+
+	tests := []struct {
+		name     string
+		after    time.Duration
+		primary  string
+		wantAuth []string
+	}{
+		{name: "Fall back to the secondary token", primary: "token_a", wantAuth: []string{"Bearer token_a", "Bearer token_b"}},
+		{name: "Do not send the rejected token again", after: 2 * time.Minute, primary: "token_a", wantAuth: []string{"Bearer token_b"}},
+		{name: "Try the primary token after the backoff", after: 15 * time.Minute, primary: "token_a", wantAuth: []string{"Bearer token_a", "Bearer token_b"}},
+		{name: "Use the changed primary token", after: 2 * time.Minute, primary: "token_c", wantAuth: []string{"Bearer token_c"}},
+	}
+	for _, tt := range tests {
+		now = now.Add(tt.after)
+		primary = tt.primary
+		gotAuth = nil
+		if err := c.Get("crm/v3/objects/contacts/1", nil, nil); err != nil {
+			t.Fatalf("%s: Get() error: %s", tt.name, err)
+		}
+		if diff := cmp.Diff(tt.wantAuth, gotAuth); diff != "" {
+			t.Errorf("%s: Authorization mismatch (-want +got):%s", tt.name, diff)
+		}
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := ioutil.WriteFile(path, []byte("pat-na1-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := hubspot.FileTokenSource(path).Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() error: %s", err)
+	}
+	if got != "pat-na1-token" {
+		t.Errorf("Token() mismatch: want pat-na1-token got %s", got)
+	}
+
+	if _, err := hubspot.FileTokenSource(filepath.Join(t.TempDir(), "missing")).Token(context.Background()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Token() error mismatch: want ErrNotExist got %v", err)
+	}
+}
+
+func TestEnvTokenSource(t *testing.T) {
+	os.Setenv("HUBSPOT_TEST_TOKEN", "pat-na1-token")
+	defer os.Unsetenv("HUBSPOT_TEST_TOKEN")
+
+	got, err := hubspot.EnvTokenSource("HUBSPOT_TEST_TOKEN").Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() error: %s", err)
+	}
+	if got != "pat-na1-token" {
+		t.Errorf("Token() mismatch: want pat-na1-token got %s", got)
+	}
+
+	_, err = hubspot.EnvTokenSource("HUBSPOT_TEST_UNSET").Token(context.Background())
+	if wantErr := errors.New("the environment variable HUBSPOT_TEST_UNSET is not set"); err == nil || err.Error() != wantErr.Error() {
+		t.Errorf("Token() error mismatch: want %s got %v", wantErr, err)
+	}
+}
@@ -0,0 +1,168 @@
+package hubspot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// tokenLockRetryInterval is how often FileTokenStore tries to take the lock held by another process.
+const tokenLockRetryInterval = 50 * time.Millisecond
+
+// TokenStore persists the OAuth tokens, so that they survive a restart and can be shared between processes.
+// OAuthTokenManager loads the token from the store before refreshing it, and saves the refreshed token.
+type TokenStore interface {
+	// Load returns the stored token, or nil when no token has been stored yet.
+	Load(ctx context.Context) (*OAuthToken, error)
+	Save(ctx context.Context, token *OAuthToken) error
+}
+
+// TokenStoreLocker is a TokenStore which can be locked across processes.
+// OAuthTokenManager holds the lock while refreshing the token, so that a single process refreshes it
+// and the others load the refreshed token instead of using a refresh token which has been rotated.
+type TokenStoreLocker interface {
+	TokenStore
+	// Lock takes the lock, waiting for it until the context is done. The returned function releases it.
+	Lock(ctx context.Context) (unlock func(), err error)
+}
+
+// storedToken is the persisted form of OAuthToken, which includes the expiry.
+type storedToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresIn    int       `json:"expires_in"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// MemoryTokenStore is a TokenStore keeping the token in memory.
+// It can be shared by the clients of a process using the same OAuth app.
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *OAuthToken
+}
+
+var _ TokenStore = (*MemoryTokenStore)(nil)
+
+// NewMemoryTokenStore returns a new MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+func (s *MemoryTokenStore) Load(_ context.Context) (*OAuthToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == nil {
+		return nil, nil
+	}
+	token := *s.token
+	return &token, nil
+}
+
+func (s *MemoryTokenStore) Save(_ context.Context, token *OAuthToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := *token
+	s.token = &t
+	return nil
+}
+
+// FileTokenStore is a TokenStore keeping the token in a JSON file.
+// The file is replaced atomically on save, and a lock file next to it serializes the refreshes
+// of the processes sharing the token on the same host.
+type FileTokenStore struct {
+	path string
+}
+
+var _ TokenStoreLocker = (*FileTokenStore)(nil)
+
+// NewFileTokenStore returns a new FileTokenStore storing the token in the file at path.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+func (s *FileTokenStore) Load(_ context.Context) (*OAuthToken, error) {
+	b, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var st storedToken
+	if err := json.Unmarshal(b, &st); err != nil {
+		return nil, fmt.Errorf("invalid token file %s: %w", s.path, err)
+	}
+	return &OAuthToken{
+		AccessToken:  st.AccessToken,
+		RefreshToken: st.RefreshToken,
+		ExpiresIn:    st.ExpiresIn,
+		Expiry:       st.Expiry,
+	}, nil
+}
+
+func (s *FileTokenStore) Save(_ context.Context, token *OAuthToken) error {
+	b, err := json.Marshal(&storedToken{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    token.ExpiresIn,
+		Expiry:       token.Expiry,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file renamed afterward, so that a reader never sees a partially written token.
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// Lock takes an exclusive OS lock, flock on Unix and LockFileEx on Windows, on a lock file next to the token file.
+// The lock file is never removed, and the OS releases the lock of a process which crashes.
+func (s *FileTokenStore) Lock(ctx context.Context) (func(), error) {
+	f, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if locked {
+			return func() {
+				unlockFile(f)
+				f.Close()
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			f.Close()
+			return nil, ctx.Err()
+		case <-time.After(tokenLockRetryInterval):
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !windows
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris,!windows
+
+package hubspot
+
+import (
+	"errors"
+	"os"
+)
+
+func tryLockFile(_ *os.File) (bool, error) {
+	return false, errors.New("FileTokenStore cannot lock files on this platform")
+}
+
+func unlockFile(_ *os.File) error {
+	return nil
+}
@@ -0,0 +1,199 @@
+package hubspot_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTokenStore(t *testing.T) {
+	token := &hubspot.OAuthToken{
+		AccessToken:  "test_access_token",
+		RefreshToken: "test_refresh_token",
+		ExpiresIn:    21600,
+		Expiry:       time.Date(2020, 12, 31, 17, 50, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name  string
+		store hubspot.TokenStore
+	}{
+		{
+			name:  "Memory",
+			store: hubspot.NewMemoryTokenStore(),
+		},
+		{
+			name:  "File",
+			store: hubspot.NewFileTokenStore(filepath.Join(t.TempDir(), "token.json")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.store.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error: %s", err)
+			}
+			if got != nil {
+				t.Errorf("Load() must return nil before any token is saved: got %v", got)
+			}
+
+			if err := tt.store.Save(context.Background(), token); err != nil {
+				t.Fatalf("Save() error: %s", err)
+			}
+			got, err = tt.store.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error: %s", err)
+			}
+			if diff := cmp.Diff(token, got); diff != "" {
+				t.Errorf("Load() response mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestFileTokenStore_Save(t *testing.T) {
+	dir := t.TempDir()
+	store := hubspot.NewFileTokenStore(filepath.Join(dir, "token.json"))
+
+	for _, access := range []string{"first_access_token", "second_access_token"} {
+		if err := store.Save(context.Background(), &hubspot.OAuthToken{AccessToken: access, RefreshToken: "refresh_token"}); err != nil {
+			t.Fatalf("Save() error: %s", err)
+		}
+	}
+
+	got, err := store.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error: %s", err)
+	}
+	if got.AccessToken != "second_access_token" {
+		t.Errorf("Load() access token mismatch: want second_access_token got %s", got.AccessToken)
+	}
+
+	// No temporary file must be left behind.
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("files mismatch: want only token.json got %d files", len(files))
+	}
+}
+
+func TestFileTokenStore_Lock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	first := hubspot.NewFileTokenStore(path)
+	second := hubspot.NewFileTokenStore(path)
+
+	unlock, err := first.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock() error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := second.Lock(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Lock() must wait while the lock is held: want %s got %v", context.DeadlineExceeded, err)
+	}
+
+	unlock()
+	unlock, err = second.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock() error after unlock: %s", err)
+	}
+	unlock()
+
+	// The lock file is kept, and does not block the next lock.
+	if _, err := os.Stat(path + ".lock"); err != nil {
+		t.Errorf("lock file must be kept after unlock: %s", err)
+	}
+	unlock, err = first.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock() error with an existing lock file: %s", err)
+	}
+	unlock()
+}
+
+func TestOAuthTokenManager_RetrieveToken_Store(t *testing.T) {
+	config := func() *hubspot.OAuthConfig {
+		return &hubspot.OAuthConfig{
+			GrantType:    hubspot.GrantTypeRefreshToken,
+			ClientID:     "client_id",
+			ClientSecret: "client_secret",
+			RefreshToken: "refresh_token",
+		}
+	}
+
+	t.Run("Use the stored token", func(t *testing.T) {
+		store := hubspot.NewMemoryTokenStore()
+		stored := &hubspot.OAuthToken{AccessToken: "stored_access_token", RefreshToken: "stored_refresh_token", Expiry: time.Now().Add(time.Hour)}
+		if err := store.Save(context.Background(), stored); err != nil {
+			t.Fatal(err)
+		}
+
+		otm := &hubspot.OAuthTokenManager{
+			HTTPClient: &http.Client{
+				Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+					t.Error("the token must not be refreshed while the stored token is valid")
+					return nil, nil
+				}),
+			},
+			Config: config(),
+			Store:  store,
+		}
+		got, err := otm.RetrieveToken()
+		if err != nil {
+			t.Fatalf("RetrieveToken() error: %s", err)
+		}
+		if diff := cmp.Diff(stored, got); diff != "" {
+			t.Errorf("RetrieveToken() response mismatch (-want +got):%s", diff)
+		}
+		if otm.Config.RefreshToken != "stored_refresh_token" {
+			t.Errorf("Config.RefreshToken mismatch: want stored_refresh_token got %s", otm.Config.RefreshToken)
+		}
+	})
+
+	t.Run("Save the refreshed token", func(t *testing.T) {
+		store := hubspot.NewFileTokenStore(filepath.Join(t.TempDir(), "token.json"))
+		if err := store.Save(context.Background(), &hubspot.OAuthToken{AccessToken: "expired_access_token", RefreshToken: "stored_refresh_token", Expiry: time.Now().Add(-time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+
+		var gotRefreshToken string
+		otm := &hubspot.OAuthTokenManager{
+			HTTPClient: &http.Client{
+				Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+					if err := req.ParseForm(); err != nil {
+						return nil, err
+					}
+					gotRefreshToken = req.PostForm.Get("refresh_token")
+					return hubspot.NewMockHTTPClient(&hubspot.MockConfig{
+						Status: http.StatusOK,
+						Body:   []byte(`{"access_token": "new_access_token","refresh_token": "rotated_refresh_token","expires_in": 21600}`),
+					}).Transport.RoundTrip(req)
+				}),
+			},
+			Config: config(),
+			Store:  store,
+		}
+		if _, err := otm.RetrieveToken(); err != nil {
+			t.Fatalf("RetrieveToken() error: %s", err)
+		}
+		if gotRefreshToken != "stored_refresh_token" {
+			t.Errorf("refresh token mismatch: want stored_refresh_token got %s", gotRefreshToken)
+		}
+
+		saved, err := store.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error: %s", err)
+		}
+		if saved.AccessToken != "new_access_token" || saved.RefreshToken != "rotated_refresh_token" {
+			t.Errorf("saved token mismatch: got %+v", saved)
+		}
+	})
+}
@@ -0,0 +1,212 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Span attribute keys set by the Client.
+const (
+	AttributeOperation  = "hubspot.operation"
+	AttributeObjectType = "hubspot.object_type"
+	AttributeEndpoint   = "hubspot.endpoint"
+	AttributeMethod     = "http.method"
+	AttributeStatusCode = "http.status_code"
+	AttributeAttempt    = "hubspot.attempt"
+	AttributeRetryCount = "hubspot.retry_count"
+)
+
+// Tracer starts the spans of the API calls.
+// The Client opens a span per operation, e.g. CRM.Contact.Search, and a child span per HTTP attempt.
+// Adapt it to your tracing library, or use SpanRecorder in tests.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a traced unit of work started by a Tracer.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	// End ends the span. err is the error of the work, if any.
+	End(err error)
+}
+
+// NoopTracer is a Tracer which records nothing. It is used when no Tracer is set.
+type NoopTracer struct{}
+
+func (NoopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, interface{}) {}
+
+func (noopSpan) End(error) {}
+
+// SpanRecorder is a Tracer which keeps the spans in memory. It is intended for tests and is safe for concurrent use.
+type SpanRecorder struct {
+	mu    sync.Mutex
+	spans []*RecordedSpan
+}
+
+var _ Tracer = (*SpanRecorder)(nil)
+
+// NewSpanRecorder returns a new SpanRecorder.
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+// RecordedSpan is a span recorded by SpanRecorder.
+type RecordedSpan struct {
+	Name       string
+	Parent     *RecordedSpan
+	Attributes map[string]interface{}
+	Err        error
+	StartTime  time.Time
+	EndTime    time.Time
+	Ended      bool
+
+	recorder *SpanRecorder
+}
+
+type recordedSpanKey struct{}
+
+func (r *SpanRecorder) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(recordedSpanKey{}).(*RecordedSpan)
+	span := &RecordedSpan{
+		Name:       name,
+		Parent:     parent,
+		Attributes: map[string]interface{}{},
+		StartTime:  timeNow(),
+		recorder:   r,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, recordedSpanKey{}, span), span
+}
+
+// Spans returns the recorded spans in the order they have been started.
+func (r *SpanRecorder) Spans() []*RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*RecordedSpan{}, r.spans...)
+}
+
+func (s *RecordedSpan) SetAttribute(key string, value interface{}) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.Attributes[key] = value
+}
+
+func (s *RecordedSpan) End(err error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.Err = err
+	s.EndTime = timeNow()
+	s.Ended = true
+}
+
+// operation identifies the service method making a call.
+type operation struct {
+	name       string
+	objectType string
+}
+
+type operationKey struct{}
+
+// withOperation annotates the context with the service method being called.
+// An operation already set is kept, so that e.g. SearchByEmail is not reported as Search.
+func withOperation(ctx context.Context, name, objectType string) context.Context {
+	if _, ok := ctx.Value(operationKey{}).(operation); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, operationKey{}, operation{name: name, objectType: objectType})
+}
+
+type operationSpanKey struct{}
+
+func (c *Client) tracerOrNoop() Tracer {
+	if c.tracer == nil {
+		return NoopTracer{}
+	}
+	return c.tracer
+}
+
+// startOperationSpan starts the span of the operation making a request to the path.
+// When the request is one of several made by an operation traced with traceOperation, the span of the operation is used,
+// and the returned span is a no-op since the operation ends it.
+func (c *Client) startOperationSpan(ctx context.Context, method, path string) (context.Context, Span) {
+	endpoint := pathTemplate(path)
+	if span, ok := ctx.Value(operationSpanKey{}).(Span); ok {
+		span.SetAttribute(AttributeMethod, method)
+		span.SetAttribute(AttributeEndpoint, endpoint)
+		return ctx, noopSpan{}
+	}
+	op, ok := ctx.Value(operationKey{}).(operation)
+	if !ok {
+		op.name = method + " " + endpoint
+	}
+
+	ctx, span := c.startSpan(ctx, op)
+	span.SetAttribute(AttributeMethod, method)
+	span.SetAttribute(AttributeEndpoint, endpoint)
+	return ctx, span
+}
+
+// traceOperation starts the span of the operation of the context when it makes several requests,
+// e.g. a batch sent in chunks, so that their attempts are traced under a single span. The returned function ends it.
+func (c *Client) traceOperation(ctx context.Context) (context.Context, func(err error)) {
+	op, ok := ctx.Value(operationKey{}).(operation)
+	if _, traced := ctx.Value(operationSpanKey{}).(Span); !ok || traced {
+		return ctx, func(error) {}
+	}
+	ctx, span := c.startSpan(ctx, op)
+	return ctx, span.End
+}
+
+// operationTracer returns the function starting the span of an operation, e.g. for the pages of a pager.
+func (c *Client) operationTracer(name, objectType string) func(ctx context.Context) (context.Context, func(err error)) {
+	return func(ctx context.Context) (context.Context, func(err error)) {
+		return c.traceOperation(withOperation(ctx, name, objectType))
+	}
+}
+
+func (c *Client) startSpan(ctx context.Context, op operation) (context.Context, Span) {
+	ctx, span := c.tracerOrNoop().Start(ctx, op.name)
+	span.SetAttribute(AttributeOperation, op.name)
+	if op.objectType != "" {
+		span.SetAttribute(AttributeObjectType, op.objectType)
+	}
+	return context.WithValue(ctx, operationSpanKey{}, span), span
+}
+
+// traceResult sets the result of the request to the operation span.
+func traceResult(ctx context.Context, resp *http.Response, attempts int) {
+	span, ok := ctx.Value(operationSpanKey{}).(Span)
+	if !ok {
+		return
+	}
+	if resp != nil {
+		span.SetAttribute(AttributeStatusCode, resp.StatusCode)
+	}
+	span.SetAttribute(AttributeRetryCount, attempts-1)
+}
+
+// startAttemptSpan starts the span of an HTTP attempt and returns the request bound to it.
+func (c *Client) startAttemptSpan(req *http.Request, attempt int) (*http.Request, Span) {
+	ctx, span := c.tracerOrNoop().Start(req.Context(), "HTTP "+req.Method)
+	span.SetAttribute(AttributeMethod, req.Method)
+	span.SetAttribute(AttributeEndpoint, pathTemplate(req.URL.Path))
+	span.SetAttribute(AttributeAttempt, attempt)
+	if op, ok := ctx.Value(operationKey{}).(operation); ok {
+		span.SetAttribute(AttributeOperation, op.name)
+		if op.objectType != "" {
+			span.SetAttribute(AttributeObjectType, op.objectType)
+		}
+	}
+	return req.WithContext(ctx), span
+}
@@ -0,0 +1,219 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithTracer(t *testing.T) {
+	f := hubspot.MockJitter()
+	defer f()
+
+	statuses := []int{http.StatusServiceUnavailable, http.StatusOK}
+	var calls int
+	recorder := hubspot.NewSpanRecorder()
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"),
+		hubspot.WithTracer(recorder),
+		hubspot.WithRetryConfig(&hubspot.RetryConfig{InitialBackoff: time.Millisecond}),
+		hubspot.WithHTTPClient(&http.Client{
+			Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+				status := statuses[calls]
+				calls++
+				return &http.Response{
+					StatusCode: status,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+				}, nil
+			}),
+		}),
+	)
+
+	if _, err := c.CRM.Contact.Get("12345", &hubspot.Contact{}, nil); err != nil {
+		t.Fatalf("Get() error: %s", err)
+	}
+
+	spans := recorder.Spans()
+	if len(spans) != 3 {
+		t.Fatalf("recorded spans mismatch: want 3 got %d", len(spans))
+	}
+
+	type span struct {
+		name       string
+		parent     string
+		attributes map[string]interface{}
+		ended      bool
+	}
+	got := make([]span, 0, len(spans))
+	for _, s := range spans {
+		var parent string
+		if s.Parent != nil {
+			parent = s.Parent.Name
+		}
+		got = append(got, span{name: s.Name, parent: parent, attributes: s.Attributes, ended: s.Ended})
+	}
+	want := []span{
+		{
+			name: "CRM.Contact.Get",
+			attributes: map[string]interface{}{
+				hubspot.AttributeOperation:  "CRM.Contact.Get",
+				hubspot.AttributeObjectType: "contacts",
+				hubspot.AttributeMethod:     http.MethodGet,
+				hubspot.AttributeEndpoint:   "crm/v3/objects/contacts/{id}",
+				hubspot.AttributeStatusCode: http.StatusOK,
+				hubspot.AttributeRetryCount: 1,
+			},
+			ended: true,
+		},
+		{
+			name:   "HTTP GET",
+			parent: "CRM.Contact.Get",
+			attributes: map[string]interface{}{
+				hubspot.AttributeOperation:  "CRM.Contact.Get",
+				hubspot.AttributeObjectType: "contacts",
+				hubspot.AttributeMethod:     http.MethodGet,
+				hubspot.AttributeEndpoint:   "crm/v3/objects/contacts/{id}",
+				hubspot.AttributeStatusCode: http.StatusServiceUnavailable,
+				hubspot.AttributeAttempt:    1,
+			},
+			ended: true,
+		},
+		{
+			name:   "HTTP GET",
+			parent: "CRM.Contact.Get",
+			attributes: map[string]interface{}{
+				hubspot.AttributeOperation:  "CRM.Contact.Get",
+				hubspot.AttributeObjectType: "contacts",
+				hubspot.AttributeMethod:     http.MethodGet,
+				hubspot.AttributeEndpoint:   "crm/v3/objects/contacts/{id}",
+				hubspot.AttributeStatusCode: http.StatusOK,
+				hubspot.AttributeAttempt:    2,
+			},
+			ended: true,
+		},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(span{})); diff != "" {
+		t.Errorf("recorded spans mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestWithTracer_OuterOperationIsKept(t *testing.T) {
+	recorder := hubspot.NewSpanRecorder()
+	c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"),
+		hubspot.WithTracer(recorder),
+		hubspot.WithHTTPClient(hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: http.StatusOK, Body: []byte(`{}`)})),
+	)
+
+	if _, err := c.CRM.Contact.SearchByEmail("bcooper@example.com"); err != nil {
+		t.Fatalf("SearchByEmail() error: %s", err)
+	}
+	if got := recorder.Spans()[0].Name; got != "CRM.Contact.SearchByEmail" {
+		t.Errorf("operation span name mismatch: want CRM.Contact.SearchByEmail got %s", got)
+	}
+}
+
+func TestWithTracer_OneSpanPerOperation(t *testing.T) {
+	ids := make([]string, 0, 150)
+	for i := 0; i < 150; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+
+	tests := []struct {
+		name      string
+		conflict  bool
+		call      func(c *hubspot.Client) error
+		wantRoot  string
+		wantChild int
+	}{
+		{
+			name: "Batch sent in chunks",
+			call: func(c *hubspot.Client) error {
+				_, err := c.CRM.Contact.BatchRead(ids, nil)
+				return err
+			},
+			wantRoot:  "CRM.Contact.BatchRead",
+			wantChild: 2,
+		},
+		{
+			name: "Pages of a pager",
+			call: func(c *hubspot.Client) error {
+				p := hubspot.NewListPager(c.CRM.Objects(hubspot.ObjectTypeContact), nil, &hubspot.PagerOption{PageSize: 1})
+				for p.Next(context.Background()) {
+				}
+				return p.Err()
+			},
+			wantRoot:  "CRM.Objects.List",
+			wantChild: 2,
+		},
+		{
+			name:     "Create then get the existing contact",
+			conflict: true,
+			call: func(c *hubspot.Client) error {
+				_, _, err := c.CRM.Contact.CreateOrGet(&hubspot.Contact{}, nil)
+				return err
+			},
+			wantRoot:  "CRM.Contact.CreateOrGet",
+			wantChild: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			recorder := hubspot.NewSpanRecorder()
+			c, _ := hubspot.NewClient(hubspot.SetPrivateAppToken("token"),
+				hubspot.WithTracer(recorder),
+				hubspot.WithHTTPClient(&http.Client{
+					Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+						calls++
+						if tt.conflict && calls == 1 {
+							return &http.Response{
+								StatusCode: http.StatusConflict,
+								Body:       ioutil.NopCloser(bytes.NewBufferString(`{"status":"error","message":"Contact already exists. Existing ID: 1","category":"CONFLICT"}`)),
+							}, nil
+						}
+						body := `{"status":"COMPLETE","results":[{"id":"1"}]}`
+						if calls == 1 {
+							body = `{"status":"COMPLETE","results":[{"id":"1"}],"paging":{"next":{"after":"1"}}}`
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+						}, nil
+					}),
+				}),
+			)
+
+			if err := tt.call(c); err != nil {
+				t.Fatalf("call error: %s", err)
+			}
+
+			var roots []string
+			var children int
+			for _, s := range recorder.Spans() {
+				if !s.Ended {
+					t.Errorf("span %s has not ended", s.Name)
+				}
+				if s.Parent == nil {
+					roots = append(roots, s.Name)
+					continue
+				}
+				if s.Parent.Parent != nil || s.Parent.Name != tt.wantRoot {
+					t.Errorf("span %s must be a child of %s", s.Name, tt.wantRoot)
+				}
+				children++
+			}
+			if diff := cmp.Diff([]string{tt.wantRoot}, roots); diff != "" {
+				t.Errorf("root spans mismatch (-want +got):%s", diff)
+			}
+			if children != tt.wantChild {
+				t.Errorf("attempt spans mismatch: want %d got %d", tt.wantChild, children)
+			}
+		})
+	}
+}
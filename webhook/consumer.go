@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Consumer processes the events delivered by HubSpot once and in order, although HubSpot delivers them
+// at least once and out of order.
+//   - The events already processed are skipped, by their portal, subscription and event ID.
+//   - The events of a batch are processed in order of occurrence, so that the events of an object are in order.
+//   - The property changes older than the last change applied to the same property of the object are skipped.
+//
+// An event is recorded as processed only once its HandlerFunc succeeds, so a failed event is processed again
+// when HubSpot retries the batch. A DedupFlusher is flushed at the end of each batch. The batches delivered concurrently are consumed one after another.
+//
+//	h := webhook.NewHandler("YOUR_CLIENT_SECRET")
+//	h.Consumer = webhook.NewConsumer(webhook.NewFileDedupStore("/var/lib/app/webhook.json", 0))
+type Consumer struct {
+	Store DedupStore
+
+	mu sync.Mutex
+}
+
+// NewConsumer returns a new Consumer recording the processed events in the store.
+func NewConsumer(store DedupStore) *Consumer {
+	return &Consumer{Store: store}
+}
+
+// Consume calls f with the events of a batch which have not been processed yet, in order of occurrence.
+// All the events are processed even if one fails, and the errors are returned together.
+func (c *Consumer) Consume(ctx context.Context, events []Event, f HandlerFunc) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ordered := make([]Event, len(events))
+	copy(ordered, events)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].EventHeader().OccurredAt < ordered[j].EventHeader().OccurredAt
+	})
+
+	var errs []string
+	for _, e := range ordered {
+		if err := c.consume(ctx, e, f); err != nil {
+			errs = append(errs, fmt.Sprintf("event %d: %s", e.EventHeader().EventID, err))
+		}
+	}
+	if fl, ok := c.Store.(DedupFlusher); ok {
+		if err := fl.Flush(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("flush: %s", err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func (c *Consumer) consume(ctx context.Context, e Event, f HandlerFunc) error {
+	h := e.EventHeader()
+	key := eventKey(h)
+	processed, err := c.Store.Processed(ctx, key)
+	if err != nil || processed {
+		return err
+	}
+
+	propKey := propertyKey(e)
+	if propKey != "" {
+		last, err := c.Store.LastApplied(ctx, propKey)
+		if err != nil {
+			return err
+		}
+		if h.OccurredAt < last {
+			// A newer change has been applied to the property: skip the stale one.
+			return c.Store.MarkProcessed(ctx, key, "", 0)
+		}
+	}
+
+	if err := f(ctx, e); err != nil {
+		return err
+	}
+	return c.Store.MarkProcessed(ctx, key, propKey, h.OccurredAt)
+}
+
+// eventKey identifies an event. The event ID alone is not unique, e.g. a change notifies all the subscriptions
+// with the same event ID.
+func eventKey(h *Header) string {
+	return fmt.Sprintf("%d/%d/%d", h.PortalID, h.SubscriptionID, h.EventID)
+}
+
+// propertyKey identifies the property changed by a property change event, or is empty for the other events.
+func propertyKey(e Event) string {
+	switch pc := e.(type) {
+	case *PropertyChangeEvent:
+		return fmt.Sprintf("%d/%s/%d/%s", pc.PortalID, pc.ObjectType(), pc.ObjectID, pc.PropertyName)
+	case *ConversationEvent:
+		if pc.Action() == ActionPropertyChange {
+			return fmt.Sprintf("%d/conversation/%d/%s", pc.PortalID, pc.ObjectID, pc.PropertyName)
+		}
+	}
+	return ""
+}
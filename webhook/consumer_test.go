@@ -0,0 +1,140 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot/webhook"
+	"github.com/google/go-cmp/cmp"
+)
+
+func propertyChange(eventID, objectID, occurredAt int64, value string) *webhook.PropertyChangeEvent {
+	return &webhook.PropertyChangeEvent{
+		Header: webhook.Header{
+			EventID:          eventID,
+			SubscriptionID:   26,
+			SubscriptionType: "contact.propertyChange",
+			PortalID:         62515,
+			OccurredAt:       occurredAt,
+			ObjectID:         objectID,
+		},
+		PropertyName:  "lifecyclestage",
+		PropertyValue: value,
+	}
+}
+
+func TestConsumer_Consume(t *testing.T) {
+	stores := map[string]func(t *testing.T) webhook.DedupStore{
+		"Memory": func(t *testing.T) webhook.DedupStore {
+			return webhook.NewMemoryDedupStore(0)
+		},
+		"File": func(t *testing.T) webhook.DedupStore {
+			return webhook.NewFileDedupStore(filepath.Join(t.TempDir(), "webhook.json"), 0)
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			defer webhook.MockTimeNow()()
+
+			c := webhook.NewConsumer(newStore(t))
+			var applied []string
+			fail := map[int64]bool{}
+			f := func(ctx context.Context, event webhook.Event) error {
+				e := event.(*webhook.PropertyChangeEvent)
+				if fail[e.EventID] {
+					return errors.New("failed")
+				}
+				applied = append(applied, fmt.Sprintf("%d:%s", e.ObjectID, e.PropertyValue))
+				return nil
+			}
+
+			// The events of a batch are applied in order of occurrence.
+			fail[3] = true
+			err := c.Consume(context.Background(), []webhook.Event{
+				propertyChange(2, 1, 2000, "customer"),
+				propertyChange(1, 1, 1000, "lead"),
+				propertyChange(3, 2, 1000, "lead"),
+			}, f)
+			if wantErr := "event 3: failed"; err == nil || err.Error() != wantErr {
+				t.Errorf("Consume() error mismatch: want %s got %v", wantErr, err)
+			}
+
+			// The batch is delivered again along with a stale change: only the failed event is applied.
+			delete(fail, 3)
+			err = c.Consume(context.Background(), []webhook.Event{
+				propertyChange(2, 1, 2000, "customer"),
+				propertyChange(1, 1, 1000, "lead"),
+				propertyChange(3, 2, 1000, "lead"),
+				propertyChange(4, 1, 1500, "subscriber"),
+			}, f)
+			if err != nil {
+				t.Fatalf("Consume() error: %s", err)
+			}
+
+			want := []string{"1:lead", "1:customer", "2:lead"}
+			if diff := cmp.Diff(want, applied); diff != "" {
+				t.Errorf("applied events mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestFileDedupStore_Reload(t *testing.T) {
+	defer webhook.MockTimeNow()()
+
+	path := filepath.Join(t.TempDir(), "webhook.json")
+	ctx := context.Background()
+	first := webhook.NewFileDedupStore(path, 0)
+	if err := first.MarkProcessed(ctx, "62515/26/1", "62515/contacts/1/lifecyclestage", 2000); err != nil {
+		t.Fatalf("MarkProcessed() error: %s", err)
+	}
+	// The records are written once per batch, by Flush.
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("the file must not be written before Flush: %v", err)
+	}
+	if err := first.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error: %s", err)
+	}
+
+	s := webhook.NewFileDedupStore(path, 0)
+	processed, err := s.Processed(ctx, "62515/26/1")
+	if err != nil || !processed {
+		t.Errorf("Processed() mismatch: want true got %v, %v", processed, err)
+	}
+	last, err := s.LastApplied(ctx, "62515/contacts/1/lifecyclestage")
+	if err != nil || last != 2000 {
+		t.Errorf("LastApplied() mismatch: want 2000 got %d, %v", last, err)
+	}
+}
+
+func TestConsumer_FlushOncePerBatch(t *testing.T) {
+	defer webhook.MockTimeNow()()
+
+	path := filepath.Join(t.TempDir(), "webhook.json")
+	c := webhook.NewConsumer(webhook.NewFileDedupStore(path, 0))
+	err := c.Consume(context.Background(), []webhook.Event{
+		propertyChange(1, 1, 1000, "lead"),
+		propertyChange(2, 2, 1000, "lead"),
+	}, func(ctx context.Context, event webhook.Event) error {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("the file must not be written during the batch: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Consume() error: %s", err)
+	}
+
+	processed, err := webhook.NewFileDedupStore(path, 0).Processed(context.Background(), "62515/26/2")
+	if err != nil || !processed {
+		t.Errorf("Processed() after the batch mismatch: want true got %v, %v", processed, err)
+	}
+	matches, _ := filepath.Glob(path + ".*.tmp")
+	if len(matches) > 0 {
+		t.Errorf("temporary files must be removed: %v", matches)
+	}
+}
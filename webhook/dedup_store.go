@@ -0,0 +1,253 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultDedupRetention is longer than the 24 hours HubSpot keeps retrying a batch.
+	defaultDedupRetention = 72 * time.Hour
+	dedupPruneInterval    = time.Minute
+)
+
+// DedupStore remembers the events a Consumer has processed, and the time of the last change applied to each property.
+type DedupStore interface {
+	// Processed reports whether the event of the key has been processed.
+	Processed(ctx context.Context, eventKey string) (bool, error)
+	// LastApplied returns the occurredAt of the last change applied to the property of the key, or 0 if none.
+	LastApplied(ctx context.Context, propertyKey string) (int64, error)
+	// MarkProcessed records that the event of the key has been processed and,
+	// when propertyKey is not empty, that a change which occurred at occurredAt has been applied to the property.
+	MarkProcessed(ctx context.Context, eventKey, propertyKey string, occurredAt int64) error
+}
+
+// DedupFlusher is implemented by the DedupStores which persist their records once per batch rather than once per event.
+// Consumer calls Flush after each batch.
+type DedupFlusher interface {
+	Flush(ctx context.Context) error
+}
+
+// dedupRecords are the records of a DedupStore, kept until the retention has passed.
+type dedupRecords struct {
+	Events     map[string]time.Time      `json:"events"`
+	Properties map[string]propertyRecord `json:"properties"`
+}
+
+type propertyRecord struct {
+	OccurredAt int64     `json:"occurredAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func newDedupRecords() *dedupRecords {
+	return &dedupRecords{
+		Events:     map[string]time.Time{},
+		Properties: map[string]propertyRecord{},
+	}
+}
+
+func (r *dedupRecords) mark(eventKey, propertyKey string, occurredAt int64, now time.Time) {
+	r.Events[eventKey] = now
+	if propertyKey == "" {
+		return
+	}
+	if p, ok := r.Properties[propertyKey]; !ok || occurredAt > p.OccurredAt {
+		r.Properties[propertyKey] = propertyRecord{OccurredAt: occurredAt, UpdatedAt: now}
+	}
+}
+
+// prune removes the records older than the retention.
+func (r *dedupRecords) prune(retention time.Duration, now time.Time) {
+	for k, t := range r.Events {
+		if now.Sub(t) > retention {
+			delete(r.Events, k)
+		}
+	}
+	for k, p := range r.Properties {
+		if now.Sub(p.UpdatedAt) > retention {
+			delete(r.Properties, k)
+		}
+	}
+}
+
+// MemoryDedupStore is a DedupStore keeping the records in memory.
+// It is safe for concurrent use, but the records are lost on restart and not shared between processes.
+type MemoryDedupStore struct {
+	retention time.Duration
+
+	mu         sync.Mutex
+	records    *dedupRecords
+	lastPruned time.Time
+}
+
+var _ DedupStore = (*MemoryDedupStore)(nil)
+
+// NewMemoryDedupStore returns a new MemoryDedupStore keeping the records for the retention, 72 hours when it is 0.
+func NewMemoryDedupStore(retention time.Duration) *MemoryDedupStore {
+	if retention <= 0 {
+		retention = defaultDedupRetention
+	}
+	return &MemoryDedupStore{
+		retention: retention,
+		records:   newDedupRecords(),
+	}
+}
+
+func (s *MemoryDedupStore) Processed(_ context.Context, eventKey string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.records.Events[eventKey]
+	return ok, nil
+}
+
+func (s *MemoryDedupStore) LastApplied(_ context.Context, propertyKey string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.records.Properties[propertyKey].OccurredAt, nil
+}
+
+func (s *MemoryDedupStore) MarkProcessed(_ context.Context, eventKey, propertyKey string, occurredAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := timeNow()
+	s.records.mark(eventKey, propertyKey, occurredAt, now)
+	if now.Sub(s.lastPruned) > dedupPruneInterval {
+		s.records.prune(s.retention, now)
+		s.lastPruned = now
+	}
+	return nil
+}
+
+// FileDedupStore is a DedupStore keeping the records in a JSON file, so that they survive restarts.
+// MarkProcessed records the events in memory, and Flush, which Consumer calls after each batch, rewrites the file atomically.
+// It is safe for concurrent use within a process, but the file must not be shared between processes.
+type FileDedupStore struct {
+	path      string
+	retention time.Duration
+
+	mu         sync.Mutex
+	records    *dedupRecords
+	dirty      bool
+	lastPruned time.Time
+}
+
+var (
+	_ DedupStore   = (*FileDedupStore)(nil)
+	_ DedupFlusher = (*FileDedupStore)(nil)
+)
+
+// NewFileDedupStore returns a new FileDedupStore keeping the records in the file at path for the retention,
+// 72 hours when it is 0. The file is created if it does not exist.
+func NewFileDedupStore(path string, retention time.Duration) *FileDedupStore {
+	if retention <= 0 {
+		retention = defaultDedupRetention
+	}
+	return &FileDedupStore{
+		path:      path,
+		retention: retention,
+	}
+}
+
+func (s *FileDedupStore) Processed(_ context.Context, eventKey string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return false, err
+	}
+	_, ok := s.records.Events[eventKey]
+	return ok, nil
+}
+
+func (s *FileDedupStore) LastApplied(_ context.Context, propertyKey string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return 0, err
+	}
+	return s.records.Properties[propertyKey].OccurredAt, nil
+}
+
+func (s *FileDedupStore) MarkProcessed(_ context.Context, eventKey, propertyKey string, occurredAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	now := timeNow()
+	s.records.mark(eventKey, propertyKey, occurredAt, now)
+	if now.Sub(s.lastPruned) > dedupPruneInterval {
+		s.records.prune(s.retention, now)
+		s.lastPruned = now
+	}
+	s.dirty = true
+	return nil
+}
+
+// Flush writes the records marked since the last flush to the file.
+func (s *FileDedupStore) Flush(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.dirty {
+		return nil
+	}
+	if err := s.save(); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// load reads the file the first time. s.mu must be held.
+func (s *FileDedupStore) load() error {
+	if s.records != nil {
+		return nil
+	}
+	records := newDedupRecords()
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, records); err != nil {
+			return err
+		}
+	}
+	if records.Events == nil {
+		records.Events = map[string]time.Time{}
+	}
+	if records.Properties == nil {
+		records.Properties = map[string]propertyRecord{}
+	}
+	s.records = records
+	return nil
+}
+
+// save writes the records to a temporary file and renames it, so that the file is never partially written. s.mu must be held.
+func (s *FileDedupStore) save() error {
+	b, err := json.Marshal(s.records)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
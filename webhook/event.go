@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+)
+
+// Action is the kind of change an event notifies, the part of the subscription type after the object, e.g. creation.
+type Action string
+
+// Default actions
+// Reference: https://developers.hubspot.com/docs/api/webhooks#webhook-subscriptions
+const (
+	ActionCreation          Action = "creation"
+	ActionDeletion          Action = "deletion"
+	ActionPropertyChange    Action = "propertyChange"
+	ActionMerge             Action = "merge"
+	ActionAssociationChange Action = "associationChange"
+	ActionRestore           Action = "restore"
+	ActionPrivacyDeletion   Action = "privacyDeletion"
+	ActionNewMessage        Action = "newMessage"
+)
+
+// objectTypes maps the objects of the subscription types and the object type IDs to the object types.
+var objectTypes = map[string]hubspot.ObjectType{
+	"contact":   hubspot.ObjectTypeContact,
+	"company":   hubspot.ObjectTypeCompany,
+	"deal":      hubspot.ObjectTypeDeal,
+	"ticket":    hubspot.ObjectTypeTicket,
+	"product":   hubspot.ObjectTypeProduct,
+	"line_item": hubspot.ObjectTypeLineItem,
+
+	"0-1": hubspot.ObjectTypeContact,
+	"0-2": hubspot.ObjectTypeCompany,
+	"0-3": hubspot.ObjectTypeDeal,
+	"0-5": hubspot.ObjectTypeTicket,
+	"0-7": hubspot.ObjectTypeProduct,
+	"0-8": hubspot.ObjectTypeLineItem,
+}
+
+// Event is an event delivered by a webhook. It is one of the *XxxEvent types of this package.
+type Event interface {
+	EventHeader() *Header
+}
+
+// Header holds the fields common to all the events.
+type Header struct {
+	EventID          int64  `json:"eventId"`
+	SubscriptionID   int64  `json:"subscriptionId"`
+	SubscriptionType string `json:"subscriptionType"`
+	PortalID         int64  `json:"portalId"`
+	AppID            int64  `json:"appId"`
+	OccurredAt       int64  `json:"occurredAt"`
+	AttemptNumber    int    `json:"attemptNumber"`
+	ObjectID         int64  `json:"objectId"`
+	// ObjectTypeID is set by the object.* subscriptions, e.g. 0-1 for contacts or 2-123456 for a custom object.
+	ObjectTypeID string `json:"objectTypeId,omitempty"`
+	ChangeSource string `json:"changeSource,omitempty"`
+	SourceID     string `json:"sourceId,omitempty"`
+}
+
+func (h *Header) EventHeader() *Header {
+	return h
+}
+
+// Action returns the action of the subscription type, e.g. propertyChange for contact.propertyChange.
+func (h *Header) Action() Action {
+	if i := strings.Index(h.SubscriptionType, "."); i >= 0 {
+		return Action(h.SubscriptionType[i+1:])
+	}
+	return ""
+}
+
+// ObjectType returns the type of the object the event is about, e.g. hubspot.ObjectTypeContact for contact.creation.
+// The object type ID is returned for custom objects, and an empty string for conversations.
+func (h *Header) ObjectType() hubspot.ObjectType {
+	object := h.SubscriptionType
+	if i := strings.Index(object, "."); i >= 0 {
+		object = object[:i]
+	}
+	if object == "object" {
+		if t, ok := objectTypes[h.ObjectTypeID]; ok {
+			return t
+		}
+		return hubspot.ObjectType(h.ObjectTypeID)
+	}
+	return objectTypes[object]
+}
+
+// Time returns the time the event occurred at.
+func (h *Header) Time() time.Time {
+	return time.Unix(0, h.OccurredAt*int64(time.Millisecond))
+}
+
+// CreationEvent is the event of the *.creation subscriptions.
+type CreationEvent struct {
+	Header
+	ChangeFlag string `json:"changeFlag,omitempty"`
+}
+
+// DeletionEvent is the event of the *.deletion and *.privacyDeletion subscriptions.
+type DeletionEvent struct {
+	Header
+	ChangeFlag string `json:"changeFlag,omitempty"`
+}
+
+// PropertyChangeEvent is the event of the *.propertyChange subscriptions.
+type PropertyChangeEvent struct {
+	Header
+	PropertyName  string `json:"propertyName"`
+	PropertyValue string `json:"propertyValue"`
+}
+
+// MergeEvent is the event of the *.merge subscriptions.
+type MergeEvent struct {
+	Header
+	PrimaryObjectID         int64   `json:"primaryObjectId"`
+	MergedObjectIDs         []int64 `json:"mergedObjectIds"`
+	NewObjectID             int64   `json:"newObjectId"`
+	NumberOfPropertiesMoved int     `json:"numberOfPropertiesMoved"`
+}
+
+// AssociationChangeEvent is the event of the *.associationChange subscriptions.
+type AssociationChangeEvent struct {
+	Header
+	AssociationType      string `json:"associationType"`
+	AssociationCategory  string `json:"associationCategory,omitempty"`
+	AssociationTypeID    int    `json:"associationTypeId,omitempty"`
+	FromObjectTypeID     string `json:"fromObjectTypeId,omitempty"`
+	FromObjectID         int64  `json:"fromObjectId"`
+	ToObjectTypeID       string `json:"toObjectTypeId,omitempty"`
+	ToObjectID           int64  `json:"toObjectId"`
+	AssociationRemoved   bool   `json:"associationRemoved"`
+	IsPrimaryAssociation bool   `json:"isPrimaryAssociation"`
+}
+
+// RestoreEvent is the event of the *.restore subscriptions.
+type RestoreEvent struct {
+	Header
+	ChangeFlag string `json:"changeFlag,omitempty"`
+}
+
+// ConversationEvent is the event of the conversation.* subscriptions. ObjectID is the ID of the thread.
+type ConversationEvent struct {
+	Header
+	PropertyName  string `json:"propertyName,omitempty"`
+	PropertyValue string `json:"propertyValue,omitempty"`
+	MessageID     string `json:"messageId,omitempty"`
+	MessageType   string `json:"messageType,omitempty"`
+}
+
+// UnknownEvent is an event of a subscription type this package does not know. Raw is the JSON of the event.
+type UnknownEvent struct {
+	Header
+	Raw json.RawMessage `json:"-"`
+}
+
+// ParseEvents decodes the batch of events of a webhook request body.
+func ParseEvents(body []byte) ([]Event, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(body, &raws); err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0, len(raws))
+	for _, raw := range raws {
+		e, err := parseEvent(raw)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+func parseEvent(raw json.RawMessage) (Event, error) {
+	var h Header
+	if err := json.Unmarshal(raw, &h); err != nil {
+		return nil, err
+	}
+
+	var e Event
+	switch {
+	case strings.HasPrefix(h.SubscriptionType, "conversation."):
+		e = &ConversationEvent{}
+	case h.Action() == ActionCreation:
+		e = &CreationEvent{}
+	case h.Action() == ActionDeletion, h.Action() == ActionPrivacyDeletion:
+		e = &DeletionEvent{}
+	case h.Action() == ActionPropertyChange:
+		e = &PropertyChangeEvent{}
+	case h.Action() == ActionMerge:
+		e = &MergeEvent{}
+	case h.Action() == ActionAssociationChange:
+		e = &AssociationChangeEvent{}
+	case h.Action() == ActionRestore:
+		e = &RestoreEvent{}
+	default:
+		return &UnknownEvent{Header: h, Raw: raw}, nil
+	}
+	if err := json.Unmarshal(raw, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
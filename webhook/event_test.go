@@ -0,0 +1,130 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/belong-inc/go-hubspot/webhook"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseEvents(t *testing.T) {
+	body := `[
+{"eventId":1,"subscriptionId":25,"portalId":62515,"appId":54321,"occurredAt":1609415880000,"subscriptionType":"contact.creation","attemptNumber":0,"objectId":123,"changeSource":"CRM","changeFlag":"NEW"},
+{"eventId":2,"subscriptionId":26,"portalId":62515,"appId":54321,"occurredAt":1609415880000,"subscriptionType":"contact.propertyChange","attemptNumber":1,"objectId":123,"propertyName":"lifecyclestage","propertyValue":"customer","changeSource":"CRM","sourceId":"userId:1"},
+{"eventId":3,"subscriptionId":27,"portalId":62515,"appId":54321,"occurredAt":1609415880000,"subscriptionType":"deal.deletion","attemptNumber":0,"objectId":456,"changeFlag":"DELETED"},
+{"eventId":4,"subscriptionId":28,"portalId":62515,"appId":54321,"occurredAt":1609415880000,"subscriptionType":"company.merge","attemptNumber":0,"objectId":789,"primaryObjectId":789,"mergedObjectIds":[788],"newObjectId":790,"numberOfPropertiesMoved":3},
+{"eventId":5,"subscriptionId":29,"portalId":62515,"appId":54321,"occurredAt":1609415880000,"subscriptionType":"contact.associationChange","attemptNumber":0,"associationType":"CONTACT_TO_COMPANY","fromObjectId":123,"toObjectId":789,"associationRemoved":false,"isPrimaryAssociation":true},
+{"eventId":6,"subscriptionId":30,"portalId":62515,"appId":54321,"occurredAt":1609415880000,"subscriptionType":"ticket.restore","attemptNumber":0,"objectId":321},
+{"eventId":7,"subscriptionId":31,"portalId":62515,"appId":54321,"occurredAt":1609415880000,"subscriptionType":"object.creation","attemptNumber":0,"objectId":654,"objectTypeId":"2-123456"},
+{"eventId":8,"subscriptionId":32,"portalId":62515,"appId":54321,"occurredAt":1609415880000,"subscriptionType":"conversation.newMessage","attemptNumber":0,"objectId":987,"messageId":"abc","messageType":"MESSAGE","changeFlag":"NEW_MESSAGE"},
+{"eventId":9,"subscriptionId":33,"portalId":62515,"appId":54321,"occurredAt":1609415880000,"subscriptionType":"contact.unknown","attemptNumber":0,"objectId":123}
+]`
+	header := func(eventID, subscriptionID int64, subscriptionType string, objectID int64) webhook.Header {
+		return webhook.Header{
+			EventID:          eventID,
+			SubscriptionID:   subscriptionID,
+			SubscriptionType: subscriptionType,
+			PortalID:         62515,
+			AppID:            54321,
+			OccurredAt:       1609415880000,
+			ObjectID:         objectID,
+		}
+	}
+
+	contactCreation := header(1, 25, "contact.creation", 123)
+	contactCreation.ChangeSource = "CRM"
+	propertyChange := header(2, 26, "contact.propertyChange", 123)
+	propertyChange.AttemptNumber = 1
+	propertyChange.ChangeSource = "CRM"
+	propertyChange.SourceID = "userId:1"
+	objectCreation := header(7, 31, "object.creation", 654)
+	objectCreation.ObjectTypeID = "2-123456"
+
+	want := []webhook.Event{
+		&webhook.CreationEvent{Header: contactCreation, ChangeFlag: "NEW"},
+		&webhook.PropertyChangeEvent{Header: propertyChange, PropertyName: "lifecyclestage", PropertyValue: "customer"},
+		&webhook.DeletionEvent{Header: header(3, 27, "deal.deletion", 456), ChangeFlag: "DELETED"},
+		&webhook.MergeEvent{Header: header(4, 28, "company.merge", 789), PrimaryObjectID: 789, MergedObjectIDs: []int64{788}, NewObjectID: 790, NumberOfPropertiesMoved: 3},
+		&webhook.AssociationChangeEvent{Header: header(5, 29, "contact.associationChange", 0), AssociationType: "CONTACT_TO_COMPANY", FromObjectID: 123, ToObjectID: 789, IsPrimaryAssociation: true},
+		&webhook.RestoreEvent{Header: header(6, 30, "ticket.restore", 321)},
+		&webhook.CreationEvent{Header: objectCreation},
+		&webhook.ConversationEvent{Header: header(8, 32, "conversation.newMessage", 987), MessageID: "abc", MessageType: "MESSAGE"},
+		&webhook.UnknownEvent{Header: header(9, 33, "contact.unknown", 123), Raw: json.RawMessage(`{"eventId":9,"subscriptionId":33,"portalId":62515,"appId":54321,"occurredAt":1609415880000,"subscriptionType":"contact.unknown","attemptNumber":0,"objectId":123}`)},
+	}
+
+	got, err := webhook.ParseEvents([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseEvents() error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseEvents() result mismatch (-want +got):%s", diff)
+	}
+
+	if _, err := webhook.ParseEvents([]byte(`{"eventId":1}`)); err == nil {
+		t.Error("ParseEvents() must fail when the body is not an array")
+	}
+}
+
+func TestHeader(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         *webhook.Header
+		wantAction     webhook.Action
+		wantObjectType hubspot.ObjectType
+	}{
+		{
+			name:           "Contact",
+			header:         &webhook.Header{SubscriptionType: "contact.propertyChange"},
+			wantAction:     webhook.ActionPropertyChange,
+			wantObjectType: hubspot.ObjectTypeContact,
+		},
+		{
+			name:           "Company",
+			header:         &webhook.Header{SubscriptionType: "company.creation"},
+			wantAction:     webhook.ActionCreation,
+			wantObjectType: hubspot.ObjectTypeCompany,
+		},
+		{
+			name:           "Line item",
+			header:         &webhook.Header{SubscriptionType: "line_item.deletion"},
+			wantAction:     webhook.ActionDeletion,
+			wantObjectType: hubspot.ObjectTypeLineItem,
+		},
+		{
+			name:           "Standard object",
+			header:         &webhook.Header{SubscriptionType: "object.merge", ObjectTypeID: "0-3"},
+			wantAction:     webhook.ActionMerge,
+			wantObjectType: hubspot.ObjectTypeDeal,
+		},
+		{
+			name:           "Custom object",
+			header:         &webhook.Header{SubscriptionType: "object.restore", ObjectTypeID: "2-123456"},
+			wantAction:     webhook.ActionRestore,
+			wantObjectType: hubspot.ObjectType("2-123456"),
+		},
+		{
+			name:           "Conversation",
+			header:         &webhook.Header{SubscriptionType: "conversation.privacyDeletion"},
+			wantAction:     webhook.ActionPrivacyDeletion,
+			wantObjectType: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.header.Action(); got != tt.wantAction {
+				t.Errorf("Action() mismatch: want %s got %s", tt.wantAction, got)
+			}
+			if got := tt.header.ObjectType(); got != tt.wantObjectType {
+				t.Errorf("ObjectType() mismatch: want %s got %s", tt.wantObjectType, got)
+			}
+		})
+	}
+
+	h := &webhook.Header{OccurredAt: 1609415880000}
+	if want := time.Date(2020, 12, 31, 11, 58, 0, 0, time.UTC); !h.Time().Equal(want) {
+		t.Errorf("Time() mismatch: want %s got %s", want, h.Time())
+	}
+}
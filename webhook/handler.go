@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxBodySize is the size of the request bodies Handler reads at most when MaxBodySize is zero.
+const DefaultMaxBodySize = 1 << 20
+
+// HandlerFunc handles an event. Returning an error makes HubSpot deliver the batch of the event again.
+type HandlerFunc func(ctx context.Context, event Event) error
+
+// Handler is an http.Handler receiving the webhook requests of an app.
+// It validates their signatures and routes their events to the HandlerFuncs of their subscription types.
+//
+//	h := webhook.NewHandler("YOUR_CLIENT_SECRET")
+//	h.HandleFunc("contact.propertyChange", func(ctx context.Context, event webhook.Event) error { ... })
+//	http.Handle("/webhook", h)
+//
+// It responds with 401 when the signature is invalid, 400 when the body cannot be decoded, 413 when it is too large,
+// and 500 when the client secret is empty or a HandlerFunc fails so that HubSpot retries the batch. HubSpot delivers the batches at least once,
+// so the HandlerFuncs must be idempotent.
+type Handler struct {
+	// ClientSecret is the client secret of the app. The requests are rejected when it is empty.
+	ClientSecret string
+	// MaxBodySize is the size of the request bodies read at most, DefaultMaxBodySize when it is zero.
+	MaxBodySize int64
+	// URI returns the URI HubSpot sent the request to, when a proxy changes it. RequestURI is used when it is nil.
+	URI func(r *http.Request) string
+	// ErrorLog is called with the errors of the requests, when it is set.
+	ErrorLog func(r *http.Request, err error)
+	// Consumer skips the duplicate and stale events, when it is set.
+	Consumer *Consumer
+
+	handlers map[string]HandlerFunc
+}
+
+// NewHandler returns a new Handler of an app.
+func NewHandler(clientSecret string) *Handler {
+	return &Handler{
+		ClientSecret: clientSecret,
+		handlers:     map[string]HandlerFunc{},
+	}
+}
+
+// HandleFunc registers the HandlerFunc of a subscription type, e.g. contact.creation.
+// The pattern can also be the object followed by .*, e.g. conversation.*, or * for all the events.
+// The most specific pattern is used. The events without any HandlerFunc are ignored.
+func (h *Handler) HandleFunc(pattern string, f HandlerFunc) {
+	if h.handlers == nil {
+		h.handlers = map[string]HandlerFunc{}
+	}
+	h.handlers[pattern] = f
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.ClientSecret == "" {
+		h.fail(w, r, http.StatusInternalServerError, errors.New("the client secret of the webhook handler is empty"))
+		return
+	}
+
+	maxBodySize := h.MaxBodySize
+	if maxBodySize == 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		code := http.StatusBadRequest
+		if int64(len(body)) >= maxBodySize {
+			code = http.StatusRequestEntityTooLarge
+		}
+		h.fail(w, r, code, err)
+		return
+	}
+	uri := RequestURI(r)
+	if h.URI != nil {
+		uri = h.URI(r)
+	}
+	if err := ValidateRequestURI(h.ClientSecret, r, uri, body); err != nil {
+		h.fail(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	events, err := ParseEvents(body)
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.dispatch(r.Context(), events); err != nil {
+		h.fail(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dispatch calls the HandlerFuncs of the events, through the Consumer when it is set.
+// All the events are handled even if one fails, and the errors are returned together.
+func (h *Handler) dispatch(ctx context.Context, events []Event) error {
+	if h.Consumer != nil {
+		return h.Consumer.Consume(ctx, events, h.route)
+	}
+	var errs []string
+	for _, e := range events {
+		if err := h.route(ctx, e); err != nil {
+			errs = append(errs, fmt.Sprintf("event %d: %s", e.EventHeader().EventID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// route calls the HandlerFunc of the event, if any.
+func (h *Handler) route(ctx context.Context, e Event) error {
+	f := h.handler(e.EventHeader().SubscriptionType)
+	if f == nil {
+		return nil
+	}
+	return f(ctx, e)
+}
+
+// handler returns the HandlerFunc of the most specific pattern matching the subscription type.
+func (h *Handler) handler(subscriptionType string) HandlerFunc {
+	if f, ok := h.handlers[subscriptionType]; ok {
+		return f
+	}
+	if i := strings.Index(subscriptionType, "."); i >= 0 {
+		if f, ok := h.handlers[subscriptionType[:i]+".*"]; ok {
+			return f
+		}
+	}
+	return h.handlers["*"]
+}
+
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, code int, err error) {
+	if h.ErrorLog != nil {
+		h.ErrorLog(r, err)
+	}
+	http.Error(w, http.StatusText(code), code)
+}
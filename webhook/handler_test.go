@@ -0,0 +1,141 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot/webhook"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHandler(t *testing.T) {
+	defer webhook.MockTimeNow()()
+
+	body := `[{"eventId":1,"subscriptionType":"contact.creation","objectId":123},{"eventId":2,"subscriptionType":"contact.propertyChange","objectId":123,"propertyName":"email"},{"eventId":3,"subscriptionType":"conversation.creation","objectId":456},{"eventId":4,"subscriptionType":"deal.creation","objectId":789}]`
+
+	tests := []struct {
+		name        string
+		method      string
+		body        string
+		signature   string
+		emptySecret bool
+		maxBodySize int64
+		failEvent   int64
+		wantStatus  int
+		wantCalls   []string
+	}{
+		{
+			name:       "Dispatch events",
+			method:     http.MethodPost,
+			body:       body,
+			signature:  signV3(http.MethodPost, testURI, body, testTimestamp),
+			wantStatus: http.StatusNoContent,
+			wantCalls:  []string{"creation:1", "contact.propertyChange:2", "conversation:3", "all:4"},
+		},
+		{
+			name:       "Handler error",
+			method:     http.MethodPost,
+			body:       body,
+			signature:  signV3(http.MethodPost, testURI, body, testTimestamp),
+			failEvent:  2,
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  []string{"creation:1", "contact.propertyChange:2", "conversation:3", "all:4"},
+		},
+		{
+			name:       "Invalid signature",
+			method:     http.MethodPost,
+			body:       body,
+			signature:  signV3(http.MethodPost, testURI, `[]`, testTimestamp),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Invalid body",
+			method:     http.MethodPost,
+			body:       `{}`,
+			signature:  signV3(http.MethodPost, testURI, `{}`, testTimestamp),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Body too large",
+			method:      http.MethodPost,
+			body:        body,
+			signature:   signV3(http.MethodPost, testURI, body, testTimestamp),
+			maxBodySize: 64,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "Empty client secret",
+			method:      http.MethodPost,
+			body:        body,
+			signature:   signV3(http.MethodPost, testURI, body, testTimestamp),
+			emptySecret: true,
+			wantStatus:  http.StatusInternalServerError,
+		},
+		{
+			name:       "Invalid method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCalls []string
+			record := func(name string) webhook.HandlerFunc {
+				return func(ctx context.Context, event webhook.Event) error {
+					id := event.EventHeader().EventID
+					gotCalls = append(gotCalls, name+":"+strconv.FormatInt(id, 10))
+					if id == tt.failEvent {
+						return errors.New("failed")
+					}
+					return nil
+				}
+			}
+			h := webhook.NewHandler(testClientSecret)
+			if tt.emptySecret {
+				h.ClientSecret = ""
+			}
+			h.MaxBodySize = tt.maxBodySize
+			h.HandleFunc("contact.creation", record("creation"))
+			h.HandleFunc("contact.propertyChange", record("contact.propertyChange"))
+			h.HandleFunc("conversation.*", record("conversation"))
+			h.HandleFunc("*", record("all"))
+
+			r := httptest.NewRequest(tt.method, "/webhook?app=1", bytes.NewBufferString(tt.body))
+			r.Host = "example.com"
+			r.Header.Set(webhook.HeaderSignatureV3, tt.signature)
+			r.Header.Set(webhook.HeaderRequestTimestamp, testTimestamp)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status code mismatch: want %d got %d", tt.wantStatus, w.Code)
+			}
+			if diff := cmp.Diff(tt.wantCalls, gotCalls); diff != "" {
+				t.Errorf("calls mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandler_URI(t *testing.T) {
+	defer webhook.MockTimeNow()()
+
+	body := `[]`
+	h := webhook.NewHandler(testClientSecret)
+	h.URI = func(r *http.Request) string { return "https://public.example.com/hooks" + r.URL.RequestURI() }
+
+	r := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(body))
+	r.Header.Set(webhook.HeaderSignatureV3, signV3(http.MethodPost, "https://public.example.com/hooks/webhook", body, testTimestamp))
+	r.Header.Set(webhook.HeaderRequestTimestamp, testTimestamp)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status code mismatch: want %d got %d", http.StatusNoContent, w.Code)
+	}
+}
@@ -0,0 +1,11 @@
+package webhook
+
+import "time"
+
+// For test only
+
+func MockTimeNow() func() {
+	mockTime := time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return mockTime }
+	return func() { timeNow = time.Now }
+}
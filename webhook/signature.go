@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The headers of the signatures sent by HubSpot.
+// Reference: https://developers.hubspot.com/docs/api/webhooks/validating-requests
+const (
+	HeaderSignature        = "X-HubSpot-Signature"
+	HeaderSignatureVersion = "X-HubSpot-Signature-Version"
+	HeaderSignatureV3      = "X-HubSpot-Signature-v3"
+	HeaderRequestTimestamp = "X-HubSpot-Request-Timestamp"
+)
+
+const (
+	// MaxTimestampAge is how old the timestamp of a v3 signature can be. Older requests are rejected to prevent replays.
+	MaxTimestampAge = 5 * time.Minute
+)
+
+var (
+	// ErrInvalidSignature is returned when the signature does not match the request.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrExpiredTimestamp is returned when the timestamp of a v3 signature is out of MaxTimestampAge.
+	ErrExpiredTimestamp = errors.New("the webhook request timestamp has expired")
+)
+
+// timeNow is time.Now, but it has been redefined as a test variable.
+var timeNow = time.Now
+
+// ValidateSignatureV1 validates a X-HubSpot-Signature of version v1,
+// the SHA-256 hash of the client secret and the body.
+func ValidateSignatureV1(clientSecret, signature string, body []byte) error {
+	return equalSignature(signature, hashHex(clientSecret+string(body)))
+}
+
+// ValidateSignatureV2 validates a X-HubSpot-Signature of version v2,
+// the SHA-256 hash of the client secret, the method, the URI and the body.
+// uri is the exact URI HubSpot sent the request to, e.g. https://example.com/webhook?app=1.
+func ValidateSignatureV2(clientSecret, signature, method, uri string, body []byte) error {
+	return equalSignature(signature, hashHex(clientSecret+method+uri+string(body)))
+}
+
+// ValidateSignatureV3 validates a X-HubSpot-Signature-v3, the HMAC SHA-256 of the method, the URI, the body
+// and the X-HubSpot-Request-Timestamp, keyed with the client secret.
+// uri is the exact URI HubSpot sent the request to, e.g. https://example.com/webhook?app=1.
+// The characters HubSpot decodes before signing, e.g. %3A, are decoded in uri.
+// It returns ErrExpiredTimestamp when the timestamp is out of MaxTimestampAge.
+func ValidateSignatureV3(clientSecret, signature, method, uri string, body []byte, timestamp string) error {
+	ms, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook request timestamp %q", timestamp)
+	}
+	age := timeNow().Sub(time.Unix(0, ms*int64(time.Millisecond)))
+	if age > MaxTimestampAge || age < -MaxTimestampAge {
+		return ErrExpiredTimestamp
+	}
+
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write([]byte(method + v3URIDecoder.Replace(uri) + string(body) + timestamp))
+	return equalSignature(signature, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+// ValidateRequest validates the signature of a webhook request with the newest version it has.
+// body is the body read from the request. The URI of the request is https:// followed by its host and request URI,
+// use ValidateRequestURI when a proxy changes them.
+func ValidateRequest(clientSecret string, r *http.Request, body []byte) error {
+	return ValidateRequestURI(clientSecret, r, RequestURI(r), body)
+}
+
+// ValidateRequestURI validates the signature of a webhook request sent by HubSpot to uri.
+func ValidateRequestURI(clientSecret string, r *http.Request, uri string, body []byte) error {
+	if signature := r.Header.Get(HeaderSignatureV3); signature != "" {
+		return ValidateSignatureV3(clientSecret, signature, r.Method, uri, body, r.Header.Get(HeaderRequestTimestamp))
+	}
+
+	signature := r.Header.Get(HeaderSignature)
+	if signature == "" {
+		return ErrInvalidSignature
+	}
+	switch v := r.Header.Get(HeaderSignatureVersion); v {
+	case "v1":
+		return ValidateSignatureV1(clientSecret, signature, body)
+	case "v2":
+		return ValidateSignatureV2(clientSecret, signature, r.Method, uri, body)
+	default:
+		return fmt.Errorf("unsupported webhook signature version %q", v)
+	}
+}
+
+// RequestURI returns the URI HubSpot sent the request to, assuming it is served over HTTPS as HubSpot requires.
+func RequestURI(r *http.Request) string {
+	return "https://" + r.Host + r.URL.RequestURI()
+}
+
+// v3URIDecoder decodes the characters HubSpot decodes in the URI of a v3 signature.
+var v3URIDecoder = strings.NewReplacer(
+	"%3A", ":", "%3a", ":",
+	"%2F", "/", "%2f", "/",
+	"%3F", "?", "%3f", "?",
+	"%40", "@",
+	"%21", "!",
+	"%24", "$",
+	"%27", "'",
+	"%28", "(",
+	"%29", ")",
+	"%2A", "*", "%2a", "*",
+	"%2C", ",", "%2c", ",",
+	"%3B", ";", "%3b", ";",
+)
+
+func hashHex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// equalSignature compares the signatures in constant time.
+func equalSignature(got, want string) error {
+	if subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package webhook_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/belong-inc/go-hubspot/webhook"
+)
+
+const (
+	testClientSecret = "yyyyyyyy-yyyy-yyyy-yyyy-yyyyyyyyyyyy"
+	testBody         = `[{"eventId":1,"subscriptionId":12345,"portalId":62515,"occurredAt":1564113600000,"subscriptionType":"contact.creation","attemptNumber":0,"objectId":123,"changeSource":"CRM","changeFlag":"NEW","appId":54321}]`
+	testURI          = "https://example.com/webhook?app=1"
+	// testTimestamp is 2020-12-31 11:58 UTC, 2 minutes before MockTimeNow.
+	testTimestamp = "1609415880000"
+)
+
+func signV2(method, uri, body string) string {
+	sum := sha256.Sum256([]byte(testClientSecret + method + uri + body))
+	return hex.EncodeToString(sum[:])
+}
+
+func signV3(method, uri, body, timestamp string) string {
+	mac := hmac.New(sha256.New, []byte(testClientSecret))
+	mac.Write([]byte(method + uri + body + timestamp))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidateSignatureV1(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		body      string
+		wantErr   error
+	}{
+		{
+			name:      "Valid signature",
+			signature: "232db2615f3d666fe21a8ec971ac7b5402d33b9a925784df3ca654d05f4817de",
+			body:      testBody,
+			wantErr:   nil,
+		},
+		{
+			name:      "Modified body",
+			signature: "232db2615f3d666fe21a8ec971ac7b5402d33b9a925784df3ca654d05f4817de",
+			body:      testBody + " ",
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "Empty signature",
+			signature: "",
+			body:      testBody,
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.ValidateSignatureV1(testClientSecret, tt.signature, []byte(tt.body))
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("ValidateSignatureV1() error mismatch: want %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateSignatureV2(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		uri       string
+		wantErr   error
+	}{
+		{
+			name:      "Valid signature",
+			signature: signV2(http.MethodPost, testURI, testBody),
+			uri:       testURI,
+			wantErr:   nil,
+		},
+		{
+			name:      "Other URI",
+			signature: signV2(http.MethodPost, testURI, testBody),
+			uri:       "https://example.com/webhook",
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.ValidateSignatureV2(testClientSecret, tt.signature, http.MethodPost, tt.uri, []byte(testBody))
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("ValidateSignatureV2() error mismatch: want %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateSignatureV3(t *testing.T) {
+	defer webhook.MockTimeNow()()
+
+	tests := []struct {
+		name      string
+		signature string
+		body      string
+		timestamp string
+		wantErr   error
+	}{
+		{
+			name:      "Valid signature",
+			signature: signV3(http.MethodPost, testURI, testBody, testTimestamp),
+			body:      testBody,
+			timestamp: testTimestamp,
+			wantErr:   nil,
+		},
+		{
+			name:      "Modified body",
+			signature: signV3(http.MethodPost, testURI, testBody, testTimestamp),
+			body:      `[]`,
+			timestamp: testTimestamp,
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "Other timestamp",
+			signature: signV3(http.MethodPost, testURI, testBody, testTimestamp),
+			body:      testBody,
+			timestamp: "1609415881000",
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+		{
+			name: "Expired timestamp",
+			// 2020-12-31 11:54 UTC
+			signature: signV3(http.MethodPost, testURI, testBody, "1609415640000"),
+			body:      testBody,
+			timestamp: "1609415640000",
+			wantErr:   webhook.ErrExpiredTimestamp,
+		},
+		{
+			name:      "Invalid timestamp",
+			signature: signV3(http.MethodPost, testURI, testBody, ""),
+			body:      testBody,
+			timestamp: "",
+			wantErr:   errors.New(`invalid webhook request timestamp ""`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.ValidateSignatureV3(testClientSecret, tt.signature, http.MethodPost, testURI, []byte(tt.body), tt.timestamp)
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("ValidateSignatureV3() error mismatch: want %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	defer webhook.MockTimeNow()()
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		wantErr error
+	}{
+		{
+			name: "v1",
+			header: http.Header{
+				webhook.HeaderSignature:        {"232db2615f3d666fe21a8ec971ac7b5402d33b9a925784df3ca654d05f4817de"},
+				webhook.HeaderSignatureVersion: {"v1"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "v2",
+			header: http.Header{
+				webhook.HeaderSignature:        {signV2(http.MethodPost, testURI, testBody)},
+				webhook.HeaderSignatureVersion: {"v2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "v3 takes precedence",
+			header: http.Header{
+				webhook.HeaderSignature:        {"invalid"},
+				webhook.HeaderSignatureVersion: {"v1"},
+				webhook.HeaderSignatureV3:      {signV3(http.MethodPost, testURI, testBody, testTimestamp)},
+				webhook.HeaderRequestTimestamp: {testTimestamp},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "No signature",
+			header:  http.Header{},
+			wantErr: webhook.ErrInvalidSignature,
+		},
+		{
+			name: "Unsupported version",
+			header: http.Header{
+				webhook.HeaderSignature:        {"232db2615f3d666fe21a8ec971ac7b5402d33b9a925784df3ca654d05f4817de"},
+				webhook.HeaderSignatureVersion: {"v9"},
+			},
+			wantErr: errors.New(`unsupported webhook signature version "v9"`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/webhook?app=1", bytes.NewBufferString(testBody))
+			r.Host = "example.com"
+			for k, v := range tt.header {
+				r.Header.Set(k, v[0])
+			}
+
+			err := webhook.ValidateRequest(testClientSecret, r, []byte(testBody))
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("ValidateRequest() error mismatch: want %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateRequest_EncodedURI(t *testing.T) {
+	defer webhook.MockTimeNow()()
+
+	const (
+		target = "/webhook?redirect=https%3A%2F%2Fexample.com%2Fcallback%3Fa%3D1&email=a%40example.com&name=a%20b"
+		// HubSpot decodes a fixed set of characters in the URI of v3 signatures, but not %20 nor %3D.
+		decodedURI = "https://example.com/webhook?redirect=https://example.com/callback?a%3D1&email=a@example.com&name=a%20b"
+		rawURI     = "https://example.com" + target
+	)
+	tests := []struct {
+		name   string
+		header http.Header
+	}{
+		{
+			name: "v2 signs the raw URI",
+			header: http.Header{
+				webhook.HeaderSignature:        {signV2(http.MethodPost, rawURI, testBody)},
+				webhook.HeaderSignatureVersion: {"v2"},
+			},
+		},
+		{
+			name: "v3 signs the decoded URI",
+			header: http.Header{
+				webhook.HeaderSignatureV3:      {signV3(http.MethodPost, decodedURI, testBody, testTimestamp)},
+				webhook.HeaderRequestTimestamp: {testTimestamp},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString(testBody))
+			r.Host = "example.com"
+			for k, v := range tt.header {
+				r.Header.Set(k, v[0])
+			}
+
+			if err := webhook.ValidateRequest(testClientSecret, r, []byte(testBody)); err != nil {
+				t.Errorf("ValidateRequest() error: %s", err)
+			}
+		})
+	}
+}
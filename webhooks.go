@@ -0,0 +1,239 @@
+package hubspot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	webhooksBasePath = "webhooks"
+)
+
+// WebhooksService is an interface of the webhooks settings and subscriptions endpoints of the HubSpot API.
+// The client must be authenticated with the developer API key of the app account, see SetDeveloperAPIKey.
+// Reference: https://developers.hubspot.com/docs/api/webhooks
+type WebhooksService interface {
+	GetSettings(appID int64) (*WebhookSettings, error)
+	GetSettingsWithContext(ctx context.Context, appID int64) (*WebhookSettings, error)
+	UpdateSettings(appID int64, settings *WebhookSettings) (*WebhookSettings, error)
+	UpdateSettingsWithContext(ctx context.Context, appID int64, settings *WebhookSettings) (*WebhookSettings, error)
+	ListSubscriptions(appID int64) (*WebhookSubscriptionList, error)
+	ListSubscriptionsWithContext(ctx context.Context, appID int64) (*WebhookSubscriptionList, error)
+	CreateSubscription(appID int64, reqData *WebhookSubscriptionCreateRequest) (*WebhookSubscription, error)
+	CreateSubscriptionWithContext(ctx context.Context, appID int64, reqData *WebhookSubscriptionCreateRequest) (*WebhookSubscription, error)
+	UpdateSubscription(appID int64, subscriptionID int64, active bool) (*WebhookSubscription, error)
+	UpdateSubscriptionWithContext(ctx context.Context, appID int64, subscriptionID int64, active bool) (*WebhookSubscription, error)
+	DeleteSubscription(appID int64, subscriptionID int64) error
+	DeleteSubscriptionWithContext(ctx context.Context, appID int64, subscriptionID int64) error
+	BatchUpdateSubscriptions(appID int64, inputs []*WebhookSubscriptionBatchInput) (*WebhookSubscriptionList, error)
+	BatchUpdateSubscriptionsWithContext(ctx context.Context, appID int64, inputs []*WebhookSubscriptionBatchInput) (*WebhookSubscriptionList, error)
+}
+
+// WebhooksServiceOp handles communication with the webhooks related methods of the HubSpot API.
+type WebhooksServiceOp struct {
+	client       *Client
+	webhooksPath string
+}
+
+var _ WebhooksService = (*WebhooksServiceOp)(nil)
+
+func newWebhooks(c *Client) WebhooksService {
+	return &WebhooksServiceOp{
+		client:       c,
+		webhooksPath: fmt.Sprintf("%s/%s", webhooksBasePath, c.apiVersion),
+	}
+}
+
+// WebhookSettings is the target URL and the throttling of the webhooks of an app.
+type WebhookSettings struct {
+	TargetURL  string             `json:"targetUrl"`
+	Throttling *WebhookThrottling `json:"throttling"`
+	CreatedAt  *HsTime            `json:"createdAt,omitempty"`
+	UpdatedAt  *HsTime            `json:"updatedAt,omitempty"`
+}
+
+// WebhookThrottling limits the concurrent webhook requests sent to the target URL.
+type WebhookThrottling struct {
+	MaxConcurrentRequests int    `json:"maxConcurrentRequests"`
+	Period                string `json:"period,omitempty"`
+}
+
+// WebhookSubscription is a subscription of an app to the events of a type.
+type WebhookSubscription struct {
+	ID           int64   `json:"id,string"`
+	EventType    string  `json:"eventType"`
+	PropertyName string  `json:"propertyName,omitempty"`
+	Active       bool    `json:"active"`
+	CreatedAt    *HsTime `json:"createdAt,omitempty"`
+	UpdatedAt    *HsTime `json:"updatedAt,omitempty"`
+}
+
+type WebhookSubscriptionList struct {
+	Results []*WebhookSubscription `json:"results"`
+}
+
+// WebhookSubscriptionCreateRequest is the request to create a subscription.
+// PropertyName is required by the *.propertyChange event types.
+type WebhookSubscriptionCreateRequest struct {
+	EventType    string `json:"eventType"`
+	PropertyName string `json:"propertyName,omitempty"`
+	Active       bool   `json:"active"`
+}
+
+// WebhookSubscriptionBatchInput activates or pauses a subscription in BatchUpdateSubscriptions.
+type WebhookSubscriptionBatchInput struct {
+	ID     int64 `json:"id"`
+	Active bool  `json:"active"`
+}
+
+// webhookObjects maps the object types to the objects of the event types.
+var webhookObjects = map[ObjectType]string{
+	ObjectTypeContact:  "contact",
+	ObjectTypeCompany:  "company",
+	ObjectTypeDeal:     "deal",
+	ObjectTypeTicket:   "ticket",
+	ObjectTypeProduct:  "product",
+	ObjectTypeLineItem: "line_item",
+}
+
+// NewPropertyChangeSubscription returns the request to subscribe to the changes of a property of an object type,
+// e.g. the event type contact.propertyChange for ObjectTypeContact.
+// The subscription is created active.
+func NewPropertyChangeSubscription(objectType ObjectType, propertyName string) *WebhookSubscriptionCreateRequest {
+	object, ok := webhookObjects[objectType]
+	if !ok {
+		object = string(objectType)
+	}
+	return &WebhookSubscriptionCreateRequest{
+		EventType:    object + ".propertyChange",
+		PropertyName: propertyName,
+		Active:       true,
+	}
+}
+
+// GetSettings gets the webhook settings of an app.
+func (s *WebhooksServiceOp) GetSettings(appID int64) (*WebhookSettings, error) {
+	return s.GetSettingsWithContext(context.Background(), appID)
+}
+
+// GetSettingsWithContext gets the webhook settings of an app with the given context.
+func (s *WebhooksServiceOp) GetSettingsWithContext(ctx context.Context, appID int64) (*WebhookSettings, error) {
+	ctx = withOperation(ctx, "Webhooks.GetSettings", "")
+	resource := &WebhookSettings{}
+	if err := s.client.GetWithContext(ctx, s.settingsPath(appID), resource, nil); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// UpdateSettings sets the target URL and the throttling of the webhooks of an app.
+func (s *WebhooksServiceOp) UpdateSettings(appID int64, settings *WebhookSettings) (*WebhookSettings, error) {
+	return s.UpdateSettingsWithContext(context.Background(), appID, settings)
+}
+
+// UpdateSettingsWithContext sets the target URL and the throttling of the webhooks of an app with the given context.
+func (s *WebhooksServiceOp) UpdateSettingsWithContext(ctx context.Context, appID int64, settings *WebhookSettings) (*WebhookSettings, error) {
+	ctx = withOperation(ctx, "Webhooks.UpdateSettings", "")
+	if settings == nil {
+		return nil, errors.New("the webhook settings are not set")
+	}
+	reqData := &WebhookSettings{
+		TargetURL:  settings.TargetURL,
+		Throttling: settings.Throttling,
+	}
+	resource := &WebhookSettings{}
+	if err := s.client.PutWithContext(ctx, s.settingsPath(appID), reqData, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// ListSubscriptions lists the webhook subscriptions of an app.
+func (s *WebhooksServiceOp) ListSubscriptions(appID int64) (*WebhookSubscriptionList, error) {
+	return s.ListSubscriptionsWithContext(context.Background(), appID)
+}
+
+// ListSubscriptionsWithContext lists the webhook subscriptions of an app with the given context.
+func (s *WebhooksServiceOp) ListSubscriptionsWithContext(ctx context.Context, appID int64) (*WebhookSubscriptionList, error) {
+	ctx = withOperation(ctx, "Webhooks.ListSubscriptions", "")
+	resource := &WebhookSubscriptionList{}
+	if err := s.client.GetWithContext(ctx, s.subscriptionsPath(appID), resource, nil); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// CreateSubscription creates a webhook subscription of an app.
+func (s *WebhooksServiceOp) CreateSubscription(appID int64, reqData *WebhookSubscriptionCreateRequest) (*WebhookSubscription, error) {
+	return s.CreateSubscriptionWithContext(context.Background(), appID, reqData)
+}
+
+// CreateSubscriptionWithContext creates a webhook subscription of an app with the given context.
+func (s *WebhooksServiceOp) CreateSubscriptionWithContext(ctx context.Context, appID int64, reqData *WebhookSubscriptionCreateRequest) (*WebhookSubscription, error) {
+	ctx = withOperation(ctx, "Webhooks.CreateSubscription", "")
+	resource := &WebhookSubscription{}
+	if err := s.client.PostWithContext(ctx, s.subscriptionsPath(appID), reqData, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// UpdateSubscription activates or pauses a webhook subscription of an app.
+func (s *WebhooksServiceOp) UpdateSubscription(appID int64, subscriptionID int64, active bool) (*WebhookSubscription, error) {
+	return s.UpdateSubscriptionWithContext(context.Background(), appID, subscriptionID, active)
+}
+
+// UpdateSubscriptionWithContext activates or pauses a webhook subscription of an app with the given context.
+func (s *WebhooksServiceOp) UpdateSubscriptionWithContext(ctx context.Context, appID int64, subscriptionID int64, active bool) (*WebhookSubscription, error) {
+	ctx = withOperation(ctx, "Webhooks.UpdateSubscription", "")
+	reqData := struct {
+		Active bool `json:"active"`
+	}{Active: active}
+	resource := &WebhookSubscription{}
+	if err := s.client.PatchWithContext(ctx, s.subscriptionPath(appID, subscriptionID), reqData, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// DeleteSubscription deletes a webhook subscription of an app.
+func (s *WebhooksServiceOp) DeleteSubscription(appID int64, subscriptionID int64) error {
+	return s.DeleteSubscriptionWithContext(context.Background(), appID, subscriptionID)
+}
+
+// DeleteSubscriptionWithContext deletes a webhook subscription of an app with the given context.
+func (s *WebhooksServiceOp) DeleteSubscriptionWithContext(ctx context.Context, appID int64, subscriptionID int64) error {
+	ctx = withOperation(ctx, "Webhooks.DeleteSubscription", "")
+	return s.client.DeleteWithContext(ctx, s.subscriptionPath(appID, subscriptionID), nil)
+}
+
+// BatchUpdateSubscriptions activates or pauses several webhook subscriptions of an app.
+func (s *WebhooksServiceOp) BatchUpdateSubscriptions(appID int64, inputs []*WebhookSubscriptionBatchInput) (*WebhookSubscriptionList, error) {
+	return s.BatchUpdateSubscriptionsWithContext(context.Background(), appID, inputs)
+}
+
+// BatchUpdateSubscriptionsWithContext activates or pauses several webhook subscriptions of an app with the given context.
+func (s *WebhooksServiceOp) BatchUpdateSubscriptionsWithContext(ctx context.Context, appID int64, inputs []*WebhookSubscriptionBatchInput) (*WebhookSubscriptionList, error) {
+	ctx = withOperation(ctx, "Webhooks.BatchUpdateSubscriptions", "")
+	reqData := struct {
+		Inputs []*WebhookSubscriptionBatchInput `json:"inputs"`
+	}{Inputs: inputs}
+	resource := &WebhookSubscriptionList{}
+	if err := s.client.PostWithContext(ctx, s.subscriptionsPath(appID)+"/batch/update", reqData, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+func (s *WebhooksServiceOp) settingsPath(appID int64) string {
+	return fmt.Sprintf("%s/%d/settings", s.webhooksPath, appID)
+}
+
+func (s *WebhooksServiceOp) subscriptionsPath(appID int64) string {
+	return fmt.Sprintf("%s/%d/subscriptions", s.webhooksPath, appID)
+}
+
+func (s *WebhooksServiceOp) subscriptionPath(appID, subscriptionID int64) string {
+	return fmt.Sprintf("%s/%d/subscriptions/%d", s.webhooksPath, appID, subscriptionID)
+}
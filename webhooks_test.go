@@ -0,0 +1,166 @@
+package hubspot_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/belong-inc/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWebhooksServiceOp(t *testing.T) {
+	createdAt := hubspot.NewTime(time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC))
+	subscription := `{"id":"123","eventType":"contact.propertyChange","propertyName":"lifecyclestage","active":true,"createdAt":"2021-05-01T12:00:00Z"}`
+
+	tests := []struct {
+		name       string
+		call       func(s hubspot.WebhooksService) (interface{}, error)
+		response   string
+		wantMethod string
+		wantPath   string
+		wantBody   string
+		want       interface{}
+	}{
+		{
+			name: "GetSettings",
+			call: func(s hubspot.WebhooksService) (interface{}, error) {
+				return s.GetSettings(456)
+			},
+			response:   `{"targetUrl":"https://example.com/webhook","throttling":{"maxConcurrentRequests":10,"period":"SECONDLY"},"createdAt":"2021-05-01T12:00:00Z"}`,
+			wantMethod: http.MethodGet,
+			wantPath:   "/webhooks/v3/456/settings",
+			want: &hubspot.WebhookSettings{
+				TargetURL:  "https://example.com/webhook",
+				Throttling: &hubspot.WebhookThrottling{MaxConcurrentRequests: 10, Period: "SECONDLY"},
+				CreatedAt:  createdAt,
+			},
+		},
+		{
+			name: "UpdateSettings",
+			call: func(s hubspot.WebhooksService) (interface{}, error) {
+				return s.UpdateSettings(456, &hubspot.WebhookSettings{
+					TargetURL:  "https://example.com/webhook",
+					Throttling: &hubspot.WebhookThrottling{MaxConcurrentRequests: 5},
+					CreatedAt:  createdAt,
+				})
+			},
+			response:   `{"targetUrl":"https://example.com/webhook","throttling":{"maxConcurrentRequests":5}}`,
+			wantMethod: http.MethodPut,
+			wantPath:   "/webhooks/v3/456/settings",
+			wantBody:   `{"targetUrl":"https://example.com/webhook","throttling":{"maxConcurrentRequests":5}}`,
+			want: &hubspot.WebhookSettings{
+				TargetURL:  "https://example.com/webhook",
+				Throttling: &hubspot.WebhookThrottling{MaxConcurrentRequests: 5},
+			},
+		},
+		{
+			name: "ListSubscriptions",
+			call: func(s hubspot.WebhooksService) (interface{}, error) {
+				return s.ListSubscriptions(456)
+			},
+			response:   `{"results":[` + subscription + `]}`,
+			wantMethod: http.MethodGet,
+			wantPath:   "/webhooks/v3/456/subscriptions",
+			want: &hubspot.WebhookSubscriptionList{
+				Results: []*hubspot.WebhookSubscription{
+					{ID: 123, EventType: "contact.propertyChange", PropertyName: "lifecyclestage", Active: true, CreatedAt: createdAt},
+				},
+			},
+		},
+		{
+			name: "CreateSubscription",
+			call: func(s hubspot.WebhooksService) (interface{}, error) {
+				return s.CreateSubscription(456, hubspot.NewPropertyChangeSubscription(hubspot.ObjectTypeContact, "lifecyclestage"))
+			},
+			response:   subscription,
+			wantMethod: http.MethodPost,
+			wantPath:   "/webhooks/v3/456/subscriptions",
+			wantBody:   `{"eventType":"contact.propertyChange","propertyName":"lifecyclestage","active":true}`,
+			want:       &hubspot.WebhookSubscription{ID: 123, EventType: "contact.propertyChange", PropertyName: "lifecyclestage", Active: true, CreatedAt: createdAt},
+		},
+		{
+			name: "UpdateSubscription",
+			call: func(s hubspot.WebhooksService) (interface{}, error) {
+				return s.UpdateSubscription(456, 123, false)
+			},
+			response:   `{"id":"123","eventType":"contact.creation","active":false}`,
+			wantMethod: http.MethodPatch,
+			wantPath:   "/webhooks/v3/456/subscriptions/123",
+			wantBody:   `{"active":false}`,
+			want:       &hubspot.WebhookSubscription{ID: 123, EventType: "contact.creation", Active: false},
+		},
+		{
+			name: "DeleteSubscription",
+			call: func(s hubspot.WebhooksService) (interface{}, error) {
+				return nil, s.DeleteSubscription(456, 123)
+			},
+			wantMethod: http.MethodDelete,
+			wantPath:   "/webhooks/v3/456/subscriptions/123",
+			want:       nil,
+		},
+		{
+			name: "BatchUpdateSubscriptions",
+			call: func(s hubspot.WebhooksService) (interface{}, error) {
+				return s.BatchUpdateSubscriptions(456, []*hubspot.WebhookSubscriptionBatchInput{{ID: 123, Active: false}})
+			},
+			response:   `{"status":"COMPLETE","results":[{"id":"123","eventType":"contact.creation","active":false}]}`,
+			wantMethod: http.MethodPost,
+			wantPath:   "/webhooks/v3/456/subscriptions/batch/update",
+			wantBody:   `{"inputs":[{"id":123,"active":false}]}`,
+			want: &hubspot.WebhookSubscriptionList{
+				Results: []*hubspot.WebhookSubscription{{ID: 123, EventType: "contact.creation", Active: false}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotPath, gotKey, gotBody string
+			c, _ := hubspot.NewClient(hubspot.SetDeveloperAPIKey("developer_key"), hubspot.WithHTTPClient(&http.Client{
+				Transport: contextRoundTripper(func(req *http.Request) (*http.Response, error) {
+					gotMethod, gotPath, gotKey = req.Method, req.URL.Path, req.URL.Query().Get("hapikey")
+					if req.Body != nil {
+						b, _ := ioutil.ReadAll(req.Body)
+						gotBody = string(b)
+					}
+					mock := &hubspot.MockConfig{Status: http.StatusOK, Body: []byte(tt.response)}
+					if tt.response == "" {
+						mock.Status = http.StatusNoContent
+					}
+					return hubspot.NewMockHTTPClient(mock).Transport.RoundTrip(req)
+				}),
+			}))
+
+			got, err := tt.call(c.Webhooks)
+			if err != nil {
+				t.Fatalf("%s() error: %s", tt.name, err)
+			}
+			if gotMethod != tt.wantMethod || gotPath != tt.wantPath {
+				t.Errorf("request mismatch: want %s %s got %s %s", tt.wantMethod, tt.wantPath, gotMethod, gotPath)
+			}
+			if gotKey != "developer_key" {
+				t.Errorf("hapikey mismatch: want developer_key got %s", gotKey)
+			}
+			if gotBody != tt.wantBody {
+				t.Errorf("body mismatch: want %s got %s", tt.wantBody, gotBody)
+			}
+			if tt.want == nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmpTimeOption); diff != "" {
+				t.Errorf("%s() response mismatch (-want +got):%s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestWebhooksServiceOp_UpdateSettingsNil(t *testing.T) {
+	c, _ := hubspot.NewClient(hubspot.SetDeveloperAPIKey("developer_key"))
+	_, err := c.Webhooks.UpdateSettings(456, nil)
+	if wantErr := errors.New("the webhook settings are not set"); !reflect.DeepEqual(wantErr, err) {
+		t.Errorf("UpdateSettings() error mismatch: want %s got %v", wantErr, err)
+	}
+}